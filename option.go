@@ -1,15 +1,24 @@
 package socketgateway
 
 import (
+	"errors"
 	"github.com/obnahsgnaw/application/pkg/utils"
 	"github.com/obnahsgnaw/application/servertype"
 	"github.com/obnahsgnaw/goutils/security/coder"
 	"github.com/obnahsgnaw/goutils/security/esutil"
 	"github.com/obnahsgnaw/http"
 	rpc2 "github.com/obnahsgnaw/rpc"
+	"github.com/obnahsgnaw/socketgateway/pkg/abuse"
+	"github.com/obnahsgnaw/socketgateway/pkg/authcache"
+	"github.com/obnahsgnaw/socketgateway/pkg/group"
+	"github.com/obnahsgnaw/socketgateway/pkg/jwtauth"
 	"github.com/obnahsgnaw/socketgateway/pkg/mqtt"
+	"github.com/obnahsgnaw/socketgateway/pkg/schedule"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	mqtt2 "github.com/obnahsgnaw/socketgateway/pkg/socket/engine/custom/mqtt"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"github.com/obnahsgnaw/socketgateway/pkg/transcode"
+	"github.com/obnahsgnaw/socketgateway/service/action"
 	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
 	"github.com/obnahsgnaw/socketutil/codec"
 	"time"
@@ -35,18 +44,106 @@ func ReuseAddr() Option {
 	}
 }
 
+// MaxConnections 最大连接数
+func MaxConnections(max int) Option {
+	return func(s *Server) {
+		s.admission.MaxConnections = max
+	}
+}
+
+// MaxConnectionsPerIp 单个来源ip的最大连接数, 启用ProxyProtocol时按真实ip计算
+func MaxConnectionsPerIp(max int) Option {
+	return func(s *Server) {
+		s.admission.MaxPerIp = max
+	}
+}
+
+// AcceptRate 每秒允许的新连接数及突发数
+func AcceptRate(rate float64, burst int) Option {
+	return func(s *Server) {
+		s.admission.AcceptRate = rate
+		s.admission.AcceptBurst = burst
+	}
+}
+
+// MaxUnauthenticated 未完成authenticate的最大连接数
+func MaxUnauthenticated(max int) Option {
+	return func(s *Server) {
+		s.admission.MaxUnauthenticated = max
+	}
+}
+
+// MaxFrameSize 单帧的最大字节数(ws帧, tcp等流式连接中未拆完的包, http请求体, mqtt消息), 超过则关闭连接或丢弃
+// 默认为socket.DefaultMaxFrame, 0为不限制
+func MaxFrameSize(size int) Option {
+	return func(s *Server) {
+		s.sizeLimit.MaxFrame = size
+	}
+}
+
+// MaxBufferSize 拆包缓存的最大字节数, 超过则关闭连接
+func MaxBufferSize(size int) Option {
+	return func(s *Server) {
+		s.sizeLimit.MaxBuffer = size
+	}
+}
+
+// MaxPayloadSize 解密后业务包的最大字节数, 超过则关闭连接
+func MaxPayloadSize(size int) Option {
+	return func(s *Server) {
+		s.sizeLimit.MaxPayload = size
+	}
+}
+
+// ProxyProtocol 前置代理使用proxy protocol
+func ProxyProtocol() Option {
+	return func(s *Server) {
+		s.admission.ProxyProtocol = true
+		s.addEventOption(eventhandler.ProxyProtocol())
+	}
+}
+
 func AuthCheck(interval time.Duration) Option {
 	return func(s *Server) {
 		s.addEventOption(eventhandler.AuthCheck(interval))
 	}
 }
 
+// AuthExpireCheck 检查认证过期, 过期后发送AuthExpired通知, demote为true时降级为未认证, 否则关闭连接, 需开启tick
+func AuthExpireCheck(demote bool) Option {
+	return func(s *Server) {
+		s.addEventOption(eventhandler.AuthExpireCheck(demote))
+	}
+}
+
+// LoginPolicy 设置认证类型的多端登录策略, 未设置时非user类型单端登录踢掉旧会话
+func LoginPolicy(tp string, p eventhandler.LoginPolicy) Option {
+	return func(s *Server) {
+		s.addEventOption(eventhandler.TypeLoginPolicy(tp, p))
+	}
+}
+
+// UidLoginPolicy 设置同一用户id的多端登录策略, 默认不限制
+func UidLoginPolicy(p eventhandler.LoginPolicy) Option {
+	return func(s *Server) {
+		s.addEventOption(eventhandler.UidLoginPolicy(p))
+	}
+}
+
 func Heartbeat(interval time.Duration) Option {
 	return func(s *Server) {
 		s.addEventOption(eventhandler.Heartbeat(interval))
 	}
 }
 
+// ActiveHeartbeat 主动心跳, 连接静默超过interval时发送ws ping帧或网关层Ping探测, 连续maxMissed次无响应后踢掉
+// 客户端可通过Ping协商自己的心跳间隔
+func ActiveHeartbeat(interval time.Duration, maxMissed int) Option {
+	return func(s *Server) {
+		s.addEventOption(eventhandler.ActiveHeartbeat(interval, maxMissed))
+	}
+}
+
 func Auth(p AuthProvider) Option {
 	return func(s *Server) {
 		s.authProvider = p
@@ -54,15 +151,34 @@ func Auth(p AuthProvider) Option {
 	}
 }
 
+// AuthCache 缓存AuthProvider的查询结果, 含失败结果缓存及并发查询合并
+func AuthCache(cnf authcache.Config) Option {
+	return func(s *Server) {
+		s.authCacheCnf = &cnf
+	}
+}
+
+// JwtAuth 使用内置的jwt认证, 从本地JWKS文件加载验签的key
+func JwtAuth(jwksPath string, options ...jwtauth.Option) Option {
+	return func(s *Server) {
+		p, err := jwtauth.New(jwksPath, options...)
+		if err != nil {
+			s.addErr(errors.New(s.msg("jwt auth init failed, err=" + err.Error())))
+			return
+		}
+		Auth(p)(s)
+	}
+}
+
 func DefaultUser(u *socket.AuthUser) Option {
 	return func(s *Server) {
 		s.addEventOption(eventhandler.DefaultUser(u))
 	}
 }
 
+// Tick 设置连接定时任务(心跳、认证检查等)时间轮的精度及默认间隔, 默认1秒
 func Tick(interval time.Duration) Option {
 	return func(s *Server) {
-		s.tickInterval = interval
 		s.addEventOption(eventhandler.Tick(interval))
 	}
 }
@@ -140,12 +256,62 @@ func Ticker(name string, ticker eventhandler.TickHandler) Option {
 	}
 }
 
+// IntervalTicker 添加按interval执行的连接定时任务, 每个连接独立调度
+func IntervalTicker(name string, interval time.Duration, ticker eventhandler.TickHandler) Option {
+	return func(s *Server) {
+		s.addEventOption(eventhandler.IntervalTicker(name, interval, ticker))
+	}
+}
+
 func Interceptor(i func() error) Option {
 	return func(s *Server) {
 		s.addEventOption(eventhandler.Interceptor(i))
 	}
 }
 
+// OpenInterceptor 连接建立时的拦截器, 可获取连接信息, 返回错误则关闭连接
+func OpenInterceptor(i eventhandler.OpenFunc) Option {
+	return func(s *Server) {
+		s.addEventOption(eventhandler.OpenInterceptor(i))
+	}
+}
+
+// IpAllow ip白名单, 支持ip或cidr, 非空时仅允许名单内的ip连接
+func IpAllow(cidrs ...string) Option {
+	return func(s *Server) {
+		s.addErr(s.ipFilter.Add(cidrs, nil))
+	}
+}
+
+// IpDeny ip黑名单, 支持ip或cidr
+func IpDeny(cidrs ...string) Option {
+	return func(s *Server) {
+		s.addErr(s.ipFilter.Add(nil, cidrs))
+	}
+}
+
+// AbuseDetect 异常流量检测, 连接或ip在窗口内的异常分值超过阈值时关闭连接并临时封禁ip
+func AbuseDetect(cnf abuse.Config) Option {
+	return func(s *Server) {
+		s.abuse = abuse.New(cnf)
+		s.addEventOption(eventhandler.AbuseDetector(s.abuse))
+	}
+}
+
+// ActionRule 设置action的访问控制规则, 未设置规则的action沿用默认的认证检查
+func ActionRule(id codec.ActionId, rule action.Rule) Option {
+	return func(s *Server) {
+		s.actManager.With(action.ActionRule(id, rule))
+	}
+}
+
+// ActionPatternRule 按action name匹配模式设置访问控制规则, 如 admin.*
+func ActionPatternRule(pattern string, rule action.Rule) Option {
+	return func(s *Server) {
+		s.actManager.With(action.PatternRule(pattern, rule))
+	}
+}
+
 func DefaultDataType(name codec.Name) Option {
 	return func(s *Server) {
 		s.addEventOption(eventhandler.DefaultDataType(name))
@@ -240,3 +406,102 @@ func MqttTopic(clientTopic, serverTopic mqtt2.QosTopic) Option {
 		s.mqttServerTopic = &serverTopic
 	}
 }
+
+// TenantIsolation 开启多租户隔离, 调用方需在grpc metadata的company_id中声明公司id, 不向其他公司的连接投递, 违规会记录日志并回调audit
+func TenantIsolation(audit func(tenant.Violation)) Option {
+	return func(s *Server) {
+		s.tenant = tenant.New(s.auditTenant(audit))
+	}
+}
+
+// PresenceIndex 开启集群在线索引, 各网关按interval互相同步持有的绑定id, 可通过PresenceService查询id所在的网关, 需开启rpc
+func PresenceIndex(interval time.Duration) Option {
+	return func(s *Server) {
+		if interval <= 0 {
+			interval = time.Millisecond * 200
+		}
+		s.presenceSync = interval
+	}
+}
+
+// DisableRouting 关闭跨网关路由, 目标不在当前网关时直接返回未找到, 组广播只投递到当前网关
+func DisableRouting() Option {
+	return func(s *Server) {
+		s.noRouting = true
+	}
+}
+
+// GroupDefault 设置隐式创建(加入、广播时自动创建)的组的默认配置, 如开启AutoDelete
+func GroupDefault(c group.Config) Option {
+	return func(s *Server) {
+		s.groupDefault = &c
+	}
+}
+
+// GroupSweep 设置组的清理间隔, 及自动删除组无成员多久后删除, 默认30秒、1分钟
+func GroupSweep(interval, idle time.Duration) Option {
+	return func(s *Server) {
+		s.groupSweep = interval
+		s.groupIdle = idle
+	}
+}
+
+// MulticastConcurrency 组广播、按条件广播及批量发送消息时当前网关发送的并发数, 默认eventhandler.DefaultMulticastConcurrency
+func MulticastConcurrency(n int) Option {
+	return func(s *Server) {
+		s.multicastLimit = n
+	}
+}
+
+// Transcoding 开启proto与json数据的转换, 通过Transcoder或TranscodeService注册action数据的消息描述后,
+// 推送时只需提供proto数据, json编码的连接发来的请求也会转换为proto交由handler处理
+func Transcoding() Option {
+	return func(s *Server) {
+		if s.transcoder == nil {
+			s.transcoder = transcode.New()
+			s.actManager.With(action.Transcoding(s.transcoder))
+		}
+	}
+}
+
+// TopicSubscription 开启客户端主题订阅, 客户端通过网关层的SubscribeReq订阅, 通过TopicService或Publish发布
+// acl为nil时允许订阅任意主题, limit为每个连接的最大订阅数, 0为不限制
+func TopicSubscription(acl TopicAcl, limit int) Option {
+	return func(s *Server) {
+		s.topicEnabled = true
+		s.topicAcl = acl
+		s.topicLimit = limit
+	}
+}
+
+// ScheduledDelivery 开启定时消息投递, 通过ScheduleService管理, store为nil时使用内存存储, 重启后丢失
+// o 设置投递并发数与失败重试策略
+func ScheduledDelivery(store schedule.Store, o ...schedule.Option) Option {
+	return func(s *Server) {
+		s.scheduler = schedule.New(store, o...)
+	}
+}
+
+// WriteQueue 开启连接出站队列, 写入不再阻塞调用方, 排队字节数超过高水位的慢连接按policy处理
+func WriteQueue(highWatermark, lowWatermark int, policy socket.SlowPolicy) Option {
+	return func(s *Server) {
+		s.writeQueue = &socket.WriteQueueConfig{
+			HighWatermark: highWatermark,
+			LowWatermark:  lowWatermark,
+			Policy:        policy,
+		}
+	}
+}
+
+// GracefulDrain Release时先排空而不是直接断开: 注销后通知客户端重连到reconnect(可为空),
+// 等待处理中的消息最多timeout, 再按每批batchSize个、间隔batchInterval关闭剩余连接, 各项为0时使用默认值
+func GracefulDrain(timeout time.Duration, batchSize int, batchInterval time.Duration, reconnect string) Option {
+	return func(s *Server) {
+		s.drain = &socket.DrainConfig{
+			Timeout:       timeout,
+			BatchSize:     batchSize,
+			BatchInterval: batchInterval,
+		}
+		s.drainReconnect = reconnect
+	}
+}
@@ -0,0 +1,228 @@
+package abuse
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kind 异常流量类别
+type Kind int
+
+const (
+	PackageErr    Kind = iota + 1 // 拆包失败
+	DecryptErr                    // 解密失败
+	UnknownAction                 // 未知action
+	HandshakeErr                  // 握手认证失败
+)
+
+func (k Kind) String() string {
+	switch k {
+	case PackageErr:
+		return "package_err"
+	case DecryptErr:
+		return "decrypt_err"
+	case UnknownAction:
+		return "unknown_action"
+	case HandshakeErr:
+		return "handshake_err"
+	default:
+		return "unknown"
+	}
+}
+
+// Config 滑动窗口内累计分值超过阈值时关闭连接并封禁来源ip
+type Config struct {
+	Window        time.Duration // 统计窗口
+	ConnThreshold int           // 单连接阈值, 超过则关闭连接
+	IpThreshold   int           // 单ip阈值, 超过则封禁ip
+	BanDuration   time.Duration // 封禁时长
+	Weights       map[Kind]int  // 各类别的分值, 默认1
+}
+
+// Result 上报后的处理结果
+type Result struct {
+	Score   int  // 连接当前分值
+	IpScore int  // ip当前分值
+	Close   bool // 需要关闭连接
+	Banned  bool // ip被封禁
+}
+
+// Ban 封禁信息
+type Ban struct {
+	Ip    string    `json:"ip"`
+	Until time.Time `json:"until"`
+}
+
+type record struct {
+	at    time.Time
+	score int
+}
+
+// Detector 基于评分的异常流量检测
+type Detector struct {
+	config    Config
+	mu        sync.Mutex
+	conns     map[int][]record
+	ips       map[string][]record
+	bans      map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+	listeners []func(ip string)
+}
+
+func New(c Config) *Detector {
+	if c.Window <= 0 {
+		c.Window = time.Minute
+	}
+	if c.ConnThreshold <= 0 {
+		c.ConnThreshold = 10
+	}
+	if c.IpThreshold <= 0 {
+		c.IpThreshold = 30
+	}
+	if c.BanDuration <= 0 {
+		c.BanDuration = time.Minute * 10
+	}
+	return &Detector{
+		config: c,
+		conns:  make(map[int][]record),
+		ips:    make(map[string][]record),
+		bans:   make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Report 上报一次异常
+func (d *Detector) Report(fd int, ip string, kind Kind) (r Result) {
+	score := 1
+	if w, ok := d.config.Weights[kind]; ok {
+		score = w
+	}
+	if score <= 0 {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	d.sweep(now)
+	rc := record{at: now, score: score}
+
+	var list []record
+	list, r.Score = d.window(append(d.conns[fd], rc), now)
+	d.conns[fd] = list
+	r.Close = r.Score > d.config.ConnThreshold
+
+	if ip != "" {
+		list, r.IpScore = d.window(append(d.ips[ip], rc), now)
+		d.ips[ip] = list
+		if r.IpScore > d.config.IpThreshold {
+			d.bans[ip] = now.Add(d.config.BanDuration)
+			delete(d.ips, ip)
+			r.Banned = true
+			r.Close = true
+		}
+	}
+	return
+}
+
+// Forget 连接关闭后清理连接的统计
+func (d *Detector) Forget(fd int) {
+	d.mu.Lock()
+	delete(d.conns, fd)
+	d.mu.Unlock()
+}
+
+// Banned ip是否处于封禁中
+func (d *Detector) Banned(ip string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	until, ok := d.bans[ip]
+	if !ok {
+		return false
+	}
+	if !d.now().Before(until) {
+		delete(d.bans, ip)
+		return false
+	}
+	return true
+}
+
+// Listen 手动封禁后的回调, 用于关闭该ip已建立的连接
+func (d *Detector) Listen(fn func(ip string)) {
+	if fn != nil {
+		d.mu.Lock()
+		d.listeners = append(d.listeners, fn)
+		d.mu.Unlock()
+	}
+}
+
+// Ban 手动封禁ip, duration<=0时使用配置的封禁时长
+func (d *Detector) Ban(ip string, duration time.Duration) {
+	if duration <= 0 {
+		duration = d.config.BanDuration
+	}
+	d.mu.Lock()
+	d.bans[ip] = d.now().Add(duration)
+	listeners := d.listeners
+	d.mu.Unlock()
+	for _, fn := range listeners {
+		fn(ip)
+	}
+}
+
+// Unban 解除封禁
+func (d *Detector) Unban(ip string) {
+	d.mu.Lock()
+	delete(d.bans, ip)
+	delete(d.ips, ip)
+	d.mu.Unlock()
+}
+
+// Bans 当前封禁列表
+func (d *Detector) Bans() (list []Ban) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	for ip, until := range d.bans {
+		if now.Before(until) {
+			list = append(list, Ban{Ip: ip, Until: until})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Ip < list[j].Ip
+	})
+	return
+}
+
+func (d *Detector) window(list []record, now time.Time) ([]record, int) {
+	start := now.Add(-d.config.Window)
+	i := 0
+	for i < len(list) && !list[i].at.After(start) {
+		i++
+	}
+	list = list[i:]
+	score := 0
+	for _, rc := range list {
+		score += rc.score
+	}
+	return list, score
+}
+
+// sweep 定期清理过期的统计和封禁
+func (d *Detector) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < d.config.Window {
+		return
+	}
+	d.lastSweep = now
+	for ip, list := range d.ips {
+		if list, _ = d.window(list, now); len(list) == 0 {
+			delete(d.ips, ip)
+		}
+	}
+	for ip, until := range d.bans {
+		if !now.Before(until) {
+			delete(d.bans, ip)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package abuse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetector(t *testing.T) {
+	now := time.Now()
+	d := New(Config{
+		Window:        time.Second,
+		ConnThreshold: 2,
+		IpThreshold:   4,
+		BanDuration:   time.Minute,
+		Weights:       map[Kind]int{HandshakeErr: 2},
+	})
+	d.now = func() time.Time { return now }
+
+	if r := d.Report(1, "1.1.1.1", PackageErr); r.Close || r.Score != 1 {
+		t.Error("need not close but closed")
+		return
+	}
+	if r := d.Report(1, "1.1.1.1", HandshakeErr); !r.Close || r.Banned {
+		t.Error("conn need close but not")
+		return
+	}
+	now = now.Add(time.Second * 2)
+	if r := d.Report(2, "1.1.1.1", PackageErr); r.Close || r.IpScore != 1 {
+		t.Error("window not slide")
+		return
+	}
+	d.Report(3, "1.1.1.1", DecryptErr)
+	d.Report(4, "1.1.1.1", UnknownAction)
+	if r := d.Report(5, "1.1.1.1", HandshakeErr); !r.Banned {
+		t.Error("ip need banned but not")
+		return
+	}
+	if !d.Banned("1.1.1.1") || len(d.Bans()) != 1 {
+		t.Error("ban not recorded")
+		return
+	}
+	d.Unban("1.1.1.1")
+	if d.Banned("1.1.1.1") {
+		t.Error("unban failed")
+		return
+	}
+	d.Ban("2.2.2.2", time.Second)
+	now = now.Add(time.Second * 2)
+	if d.Banned("2.2.2.2") {
+		t.Error("ban need expired")
+	}
+}
+
+func TestDetectorListen(t *testing.T) {
+	d := New(Config{})
+	var banned []string
+	d.Listen(func(ip string) {
+		banned = append(banned, ip)
+	})
+	d.Ban("10.0.0.1", time.Minute)
+	if len(banned) != 1 || banned[0] != "10.0.0.1" || !d.Banned("10.0.0.1") {
+		t.Error("need notify manual ban", banned)
+	}
+}
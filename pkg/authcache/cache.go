@@ -0,0 +1,294 @@
+package authcache
+
+import (
+	"container/list"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"golang.org/x/sync/singleflight"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken token本身无效, 重试也不会成功, Provider返回包装了它的错误时缓存失败结果
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// Provider 被缓存的用户查询
+type Provider interface {
+	GetAuthedUser(token string) (*socket.AuthUser, error)
+	GetIdUser(uid string) (*socket.AuthUser, error)
+}
+
+type expiringProvider interface {
+	GetAuthedUserWithExpire(token string) (*socket.AuthUser, time.Time, error)
+}
+
+// invalidTokenError 错误可以自行声明是否为确定的无效token
+type invalidTokenError interface {
+	InvalidToken() bool
+}
+
+// Config 缓存配置
+type Config struct {
+	Ttl         time.Duration    // 成功结果的缓存时长, 默认1分钟
+	NegativeTtl time.Duration    // 失败结果的缓存时长, 默认5秒, 小于0不缓存
+	MaxEntries  int              // 最大缓存条数, 默认10000, 超出时淘汰最久未使用的
+	Negative    func(error) bool // 失败结果是否可以缓存, 默认只缓存确定的无效token, 服务不可用、超时等临时错误不缓存
+}
+
+type entry struct {
+	user     *socket.AuthUser
+	expireAt time.Time // token过期时间
+	err      error
+	until    time.Time // 缓存过期时间
+}
+
+type result struct {
+	user     *socket.AuthUser
+	expireAt time.Time
+	epoch    uint64 // 查询开始时的失效代数
+}
+
+// Cache 带ttl、失败缓存和并发合并的AuthProvider装饰器
+type Cache struct {
+	p      Provider
+	config Config
+	mu     sync.Mutex
+	tokens *lru
+	ids    *lru
+	group  singleflight.Group
+	now    func() time.Time
+
+	// 查询进行中发生的失效, 查询结果早于失效时不写入缓存, 没有进行中的查询时清空
+	epoch        uint64
+	loading      int
+	purged       uint64
+	invalidUsers map[uint]uint64
+	invalidKeys  map[string]uint64
+}
+
+func New(p Provider, c Config) *Cache {
+	if c.Ttl <= 0 {
+		c.Ttl = time.Minute
+	}
+	if c.NegativeTtl == 0 {
+		c.NegativeTtl = time.Second * 5
+	}
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = 10000
+	}
+	if c.Negative == nil {
+		c.Negative = IsInvalidToken
+	}
+	return &Cache{
+		p:            p,
+		config:       c,
+		tokens:       newLru(c.MaxEntries),
+		ids:          newLru(c.MaxEntries),
+		now:          time.Now,
+		invalidUsers: make(map[uint]uint64),
+		invalidKeys:  make(map[string]uint64),
+	}
+}
+
+// IsInvalidToken 是否为确定的无效token错误
+func IsInvalidToken(err error) bool {
+	if errors.Is(err, ErrInvalidToken) {
+		return true
+	}
+	var ie invalidTokenError
+	return errors.As(err, &ie) && ie.InvalidToken()
+}
+
+func (c *Cache) GetAuthedUser(token string) (*socket.AuthUser, error) {
+	u, _, err := c.GetAuthedUserWithExpire(token)
+	return u, err
+}
+
+func (c *Cache) GetAuthedUserWithExpire(token string) (*socket.AuthUser, time.Time, error) {
+	e := c.load(c.tokens, "token:", token, func() (*socket.AuthUser, time.Time, error) {
+		if p, ok := c.p.(expiringProvider); ok {
+			return p.GetAuthedUserWithExpire(token)
+		}
+		u, err := c.p.GetAuthedUser(token)
+		return u, time.Time{}, err
+	})
+	return copyUser(e.user), e.expireAt, e.err
+}
+
+func (c *Cache) GetIdUser(uid string) (*socket.AuthUser, error) {
+	e := c.load(c.ids, "id:", uid, func() (*socket.AuthUser, time.Time, error) {
+		u, err := c.p.GetIdUser(uid)
+		return u, time.Time{}, err
+	})
+	return copyUser(e.user), e.err
+}
+
+// InvalidateToken 删除token的缓存
+func (c *Cache) InvalidateToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens.remove(token)
+	if c.loading > 0 {
+		c.epoch++
+		c.invalidKeys["token:"+token] = c.epoch
+	}
+}
+
+// InvalidateUser 删除用户相关的全部缓存
+func (c *Cache) InvalidateUser(id uint) {
+	uid := strconv.Itoa(int(id))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids.remove(uid)
+	c.tokens.removeIf(func(e *entry) bool {
+		return e.user != nil && e.user.Id == id
+	})
+	if c.loading > 0 {
+		c.epoch++
+		c.invalidUsers[id] = c.epoch
+		c.invalidKeys["id:"+uid] = c.epoch
+	}
+}
+
+// Purge 清空缓存
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens = newLru(c.config.MaxEntries)
+	c.ids = newLru(c.config.MaxEntries)
+	c.epoch++
+	c.purged = c.epoch
+}
+
+func (c *Cache) load(m *lru, prefix, key string, fn func() (*socket.AuthUser, time.Time, error)) *entry {
+	now := c.now()
+	c.mu.Lock()
+	e, ok := m.get(key)
+	if ok && now.Before(e.until) {
+		c.mu.Unlock()
+		return e
+	}
+	c.loading++
+	c.mu.Unlock()
+
+	v, err, _ := c.group.Do(prefix+key, func() (interface{}, error) {
+		c.mu.Lock()
+		epoch := c.epoch
+		c.mu.Unlock()
+		u, expireAt, err := fn()
+		return result{user: u, expireAt: expireAt, epoch: epoch}, err
+	})
+	r := v.(result)
+	e = &entry{user: r.user, expireAt: r.expireAt, err: err}
+	cacheable := true
+	if err != nil {
+		if c.config.NegativeTtl < 0 || !c.config.Negative(err) {
+			cacheable = false
+		}
+		e.until = now.Add(c.config.NegativeTtl)
+	} else {
+		e.until = now.Add(c.config.Ttl)
+		if !r.expireAt.IsZero() && r.expireAt.Before(e.until) {
+			e.until = r.expireAt
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cacheable && !c.stale(prefix+key, r) {
+		m.add(key, e)
+	}
+	if c.loading--; c.loading == 0 {
+		c.invalidUsers = make(map[uint]uint64)
+		c.invalidKeys = make(map[string]uint64)
+	}
+	return e
+}
+
+// stale 查询开始后key或结果的用户被失效过
+func (c *Cache) stale(key string, r result) bool {
+	if c.purged > r.epoch || c.invalidKeys[key] > r.epoch {
+		return true
+	}
+	return r.user != nil && c.invalidUsers[r.user.Id] > r.epoch
+}
+
+// lru 按最近使用排序的缓存, 插入和淘汰均为O(1)
+type lru struct {
+	max   int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruItem struct {
+	key string
+	e   *entry
+}
+
+func newLru(max int) *lru {
+	return &lru{
+		max:   max,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (l *lru) get(key string) (*entry, bool) {
+	el, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	l.ll.MoveToFront(el)
+	return el.Value.(*lruItem).e, true
+}
+
+// add 写入并置为最近使用, 超出上限时淘汰最久未使用的
+func (l *lru) add(key string, e *entry) {
+	if el, ok := l.items[key]; ok {
+		el.Value.(*lruItem).e = e
+		l.ll.MoveToFront(el)
+		return
+	}
+	if l.ll.Len() >= l.max {
+		if oldest := l.ll.Back(); oldest != nil {
+			l.ll.Remove(oldest)
+			delete(l.items, oldest.Value.(*lruItem).key)
+		}
+	}
+	l.items[key] = l.ll.PushFront(&lruItem{key: key, e: e})
+}
+
+func (l *lru) remove(key string) {
+	if el, ok := l.items[key]; ok {
+		l.ll.Remove(el)
+		delete(l.items, key)
+	}
+}
+
+func (l *lru) removeIf(fn func(e *entry) bool) {
+	for key, el := range l.items {
+		if fn(el.Value.(*lruItem).e) {
+			l.ll.Remove(el)
+			delete(l.items, key)
+		}
+	}
+}
+
+func (l *lru) len() int {
+	return l.ll.Len()
+}
+
+func copyUser(u *socket.AuthUser) *socket.AuthUser {
+	if u == nil {
+		return nil
+	}
+	u1 := *u
+	if u.Attr != nil {
+		u1.Attr = make(map[string]string, len(u.Attr))
+		for k, v := range u.Attr {
+			u1.Attr[k] = v
+		}
+	}
+	return &u1
+}
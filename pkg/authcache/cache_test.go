@@ -0,0 +1,118 @@
+package authcache
+
+import (
+	"errors"
+	"fmt"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type provider struct {
+	calls int64
+}
+
+func (p *provider) GetAuthedUser(token string) (*socket.AuthUser, error) {
+	atomic.AddInt64(&p.calls, 1)
+	time.Sleep(time.Millisecond * 20)
+	if token == "bad" {
+		return nil, fmt.Errorf("token rejected: %w", ErrInvalidToken)
+	}
+	if token == "down" {
+		return nil, errors.New("user service unavailable")
+	}
+	return &socket.AuthUser{Id: 1, Attr: map[string]string{"user_id": "1"}}, nil
+}
+
+func (p *provider) GetIdUser(uid string) (*socket.AuthUser, error) {
+	atomic.AddInt64(&p.calls, 1)
+	return &socket.AuthUser{Id: 1}, nil
+}
+
+func TestCache(t *testing.T) {
+	p := &provider{}
+	c := New(p, Config{Ttl: time.Minute, NegativeTtl: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = c.GetAuthedUser("good")
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt64(&p.calls); n != 1 {
+		t.Error("concurrent lookups need merged, calls=", n)
+		return
+	}
+	u, _ := c.GetAuthedUser("good")
+	u.Attr["user_id"] = "2"
+	if u1, _ := c.GetAuthedUser("good"); u1.Attr["user_id"] != "1" || atomic.LoadInt64(&p.calls) != 1 {
+		t.Error("cached user need copied")
+		return
+	}
+
+	if _, err := c.GetAuthedUser("bad"); err == nil {
+		t.Error("need error")
+		return
+	}
+	if _, err := c.GetAuthedUser("bad"); err == nil || atomic.LoadInt64(&p.calls) != 2 {
+		t.Error("error need cached")
+		return
+	}
+
+	c.InvalidateUser(1)
+	_, _ = c.GetAuthedUser("good")
+	if atomic.LoadInt64(&p.calls) != 3 {
+		t.Error("invalidate failed")
+	}
+}
+
+func TestCacheTransientError(t *testing.T) {
+	p := &provider{}
+	c := New(p, Config{NegativeTtl: time.Minute})
+	_, _ = c.GetAuthedUser("down")
+	if _, err := c.GetAuthedUser("down"); err == nil || atomic.LoadInt64(&p.calls) != 2 {
+		t.Error("transient error need not cached")
+	}
+}
+
+func TestCacheLru(t *testing.T) {
+	p := &provider{}
+	c := New(p, Config{MaxEntries: 2})
+	_, _ = c.GetIdUser("1")
+	_, _ = c.GetIdUser("2")
+	_, _ = c.GetIdUser("1")
+	_, _ = c.GetIdUser("3")
+	if c.ids.len() != 2 {
+		t.Error("need bounded entries", c.ids.len())
+		return
+	}
+	if _, ok := c.ids.get("2"); ok {
+		t.Error("need evict least recently used")
+		return
+	}
+	if _, ok := c.ids.get("1"); !ok {
+		t.Error("recently used need kept")
+	}
+}
+
+func TestCacheInvalidateInflight(t *testing.T) {
+	p := &provider{}
+	c := New(p, Config{})
+	done := make(chan struct{})
+	go func() {
+		_, _ = c.GetAuthedUser("good")
+		close(done)
+	}()
+	time.Sleep(time.Millisecond * 5)
+	c.InvalidateUser(1)
+	<-done
+	_, _ = c.GetAuthedUser("good")
+	if n := atomic.LoadInt64(&p.calls); n != 2 {
+		t.Error("result loaded before invalidate need not cached, calls=", n)
+	}
+}
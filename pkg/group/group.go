@@ -1,18 +1,49 @@
 package group
 
 import (
+	"errors"
 	"github.com/panjf2000/gnet/v2/pkg/pool/goroutine"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+var ErrGroupFull = errors.New("group member limit exceeded")
+
 type (
 	Group struct {
-		name    string
-		members sync.Map // map[int]string
-		gs      *Groups
+		name      string
+		members   sync.Map // map[int]string
+		num       int64
+		deleted   int32
+		gs        *Groups
+		mu        sync.RWMutex
+		config    Config
+		createdAt time.Time
+		expireAt  time.Time
+		emptyAt   time.Time
 	}
 	Groups struct {
-		members sync.Map // map[string]*Group
+		members   sync.Map // map[string]*Group
+		mu        sync.RWMutex
+		listeners []func(name string, present bool)
+		def       Config
+		sweepable bool
+		sweepFns  []func()
+	}
+	// Config 组的配置
+	Config struct {
+		Meta       map[string]string // 元数据
+		Ttl        time.Duration     // 存活时间, 从设置时开始计算, 0为不过期
+		MaxMembers int               // 最大成员数, 0为不限制
+		AutoDelete bool              // 无成员时由Sweep自动删除
+	}
+	// Member 组成员
+	Member struct {
+		Fd int
+		Id string
 	}
 )
 
@@ -20,19 +51,110 @@ func New() *Groups {
 	return &Groups{}
 }
 
+// SetDefault 设置隐式创建(GetGroup)的组的默认配置
+func (gs *Groups) SetDefault(c Config) {
+	gs.mu.Lock()
+	gs.def = c
+	gs.mu.Unlock()
+}
+
 func (gs *Groups) GetGroup(name string) *Group {
 	if g, ok := gs.members.Load(name); ok {
 		return g.(*Group)
 	}
+	gs.mu.RLock()
+	def := gs.def
+	gs.mu.RUnlock()
 	g := newGroup(name)
 	g.gs = gs
-	gs.members.Store(name, g)
+	g.Configure(def)
+	if actual, loaded := gs.members.LoadOrStore(name, g); loaded {
+		return actual.(*Group)
+	}
 
 	return g
 }
 
+// Group 返回已存在的组, 不会创建
+func (gs *Groups) Group(name string) (*Group, bool) {
+	if g, ok := gs.members.Load(name); ok {
+		return g.(*Group), true
+	}
+	return nil, false
+}
+
+// CreateGroup 创建组, 已存在时更新其配置
+func (gs *Groups) CreateGroup(name string, c Config) *Group {
+	g := gs.GetGroup(name)
+	g.Configure(c)
+	return g
+}
+
+// DelGroup 删除组并清空其成员, 之后对该组的加入转到同名的新组
 func (gs *Groups) DelGroup(g *Group) {
-	gs.members.Delete(g.name)
+	if v, ok := gs.members.Load(g.name); ok && v.(*Group) == g {
+		gs.members.Delete(g.name)
+		atomic.StoreInt32(&g.deleted, 1)
+		g.RangeMembers(func(fd int, _ string) bool {
+			g.Leave(fd)
+			return true
+		})
+	}
+}
+
+// OnSweepable 首次有组设置了过期时间或自动删除时调用fn, 用于按需启动Sweep, 已有时立即调用
+func (gs *Groups) OnSweepable(fn func()) {
+	if fn == nil {
+		return
+	}
+	gs.mu.Lock()
+	if !gs.sweepable {
+		gs.sweepFns = append(gs.sweepFns, fn)
+		gs.mu.Unlock()
+		return
+	}
+	gs.mu.Unlock()
+	fn()
+}
+
+func (gs *Groups) needSweep() {
+	gs.mu.Lock()
+	if gs.sweepable {
+		gs.mu.Unlock()
+		return
+	}
+	gs.sweepable = true
+	fns := gs.sweepFns
+	gs.sweepFns = nil
+	gs.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// Listen 监听组在当前服务上由无成员变为有成员, 及由有成员变为无成员
+func (gs *Groups) Listen(fn func(name string, present bool)) {
+	if fn != nil {
+		gs.mu.Lock()
+		gs.listeners = append(gs.listeners, fn)
+		gs.mu.Unlock()
+	}
+}
+
+func (gs *Groups) changed(name string, present bool) {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	for _, fn := range gs.listeners {
+		fn(name, present)
+	}
+}
+
+// MemberNum 返回组的成员数, 组不存在时不会创建
+func (gs *Groups) MemberNum(name string) int {
+	if g, ok := gs.members.Load(name); ok {
+		return g.(*Group).MemberNum()
+	}
+	return 0
 }
 
 func (gs *Groups) RangeGroups(f func(g *Group) bool) {
@@ -41,9 +163,52 @@ func (gs *Groups) RangeGroups(f func(g *Group) bool) {
 	})
 }
 
+// List 按名称前缀分页返回组, 按名称排序, limit<=0时返回全部
+func (gs *Groups) List(prefix string, offset, limit int) (list []*Group, total int) {
+	gs.RangeGroups(func(g *Group) bool {
+		if strings.HasPrefix(g.name, prefix) {
+			list = append(list, g)
+		}
+		return true
+	})
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].name < list[j].name
+	})
+	total = len(list)
+	return page(list, offset, limit), total
+}
+
+// Sweep 删除已过期的组, 以及无成员超过idle的自动删除组, 返回删除的数量
+func (gs *Groups) Sweep(now time.Time, idle time.Duration) (n int) {
+	gs.RangeGroups(func(g *Group) bool {
+		if g.Expired(now) {
+			gs.DelGroup(g)
+			n++
+			return true
+		}
+		if !g.AutoDelete() || g.MemberNum() > 0 {
+			return true
+		}
+		g.mu.RLock()
+		emptyAt := g.emptyAt
+		g.mu.RUnlock()
+		if now.Sub(emptyAt) < idle {
+			return true
+		}
+		// 删除时加入的成员会转到同名的新组
+		gs.DelGroup(g)
+		n++
+		return true
+	})
+	return
+}
+
 func newGroup(name string) *Group {
+	now := time.Now()
 	return &Group{
-		name: name,
+		name:      name,
+		createdAt: now,
+		emptyAt:   now,
 	}
 }
 
@@ -51,12 +216,139 @@ func (g *Group) Name() string {
 	return g.name
 }
 
+// Configure 设置组的配置
+func (g *Group) Configure(c Config) {
+	meta := make(map[string]string, len(c.Meta))
+	for k, v := range c.Meta {
+		meta[k] = v
+	}
+	c.Meta = meta
+	g.mu.Lock()
+	g.config = c
+	g.expireAt = time.Time{}
+	if c.Ttl > 0 {
+		g.expireAt = time.Now().Add(c.Ttl)
+	}
+	g.mu.Unlock()
+	if g.gs != nil && (c.Ttl > 0 || c.AutoDelete) {
+		g.gs.needSweep()
+	}
+}
+
+// Config 返回组的配置
+func (g *Group) Config() Config {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	c := g.config
+	c.Meta = make(map[string]string, len(g.config.Meta))
+	for k, v := range g.config.Meta {
+		c.Meta[k] = v
+	}
+	return c
+}
+
+// Meta 返回元数据
+func (g *Group) Meta() map[string]string {
+	return g.Config().Meta
+}
+
+// MaxMembers 返回最大成员数, 0为不限制
+func (g *Group) MaxMembers() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.config.MaxMembers
+}
+
+// AutoDelete 无成员时是否自动删除
+func (g *Group) AutoDelete() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.config.AutoDelete
+}
+
+func (g *Group) CreatedAt() time.Time {
+	return g.createdAt
+}
+
+// ExpireAt 返回过期时间, 零值为不过期
+func (g *Group) ExpireAt() time.Time {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.expireAt
+}
+
+func (g *Group) Expired(now time.Time) bool {
+	expireAt := g.ExpireAt()
+	return !expireAt.IsZero() && !now.Before(expireAt)
+}
+
 func (g *Group) Join(fd int, id string) {
-	g.members.Store(fd, id)
+	_ = g.TryJoin(fd, id)
+}
+
+// TryJoin 加入组, 超出最大成员数时返回ErrGroupFull, 组已删除时加入同名的新组
+func (g *Group) TryJoin(fd int, id string) error {
+	if g.gs != nil && atomic.LoadInt32(&g.deleted) == 1 {
+		return g.gs.GetGroup(g.name).TryJoin(fd, id)
+	}
+	if _, loaded := g.members.Load(fd); loaded {
+		g.members.Store(fd, id)
+		return nil
+	}
+	max := int64(g.MaxMembers())
+	var n int64
+	for {
+		n = atomic.LoadInt64(&g.num)
+		if max > 0 && n >= max {
+			return ErrGroupFull
+		}
+		if atomic.CompareAndSwapInt64(&g.num, n, n+1) {
+			break
+		}
+	}
+	if _, loaded := g.members.LoadOrStore(fd, id); loaded {
+		atomic.AddInt64(&g.num, -1)
+		g.members.Store(fd, id)
+		return nil
+	}
+	if n == 0 && g.gs != nil {
+		g.gs.changed(g.name, true)
+	}
+	// 加入时被删除
+	if g.gs != nil && atomic.LoadInt32(&g.deleted) == 1 {
+		g.Leave(fd)
+		return g.gs.GetGroup(g.name).TryJoin(fd, id)
+	}
+	return nil
 }
 
 func (g *Group) Leave(fd int) {
-	g.members.Delete(fd)
+	if _, loaded := g.members.LoadAndDelete(fd); loaded && atomic.AddInt64(&g.num, -1) == 0 {
+		g.mu.Lock()
+		g.emptyAt = time.Now()
+		g.mu.Unlock()
+		if g.gs != nil {
+			g.gs.changed(g.name, false)
+		}
+	}
+}
+
+// MemberNum 返回成员数
+func (g *Group) MemberNum() int {
+	return int(atomic.LoadInt64(&g.num))
+}
+
+// Members 分页返回成员, 按fd排序, limit<=0时返回全部
+func (g *Group) Members(offset, limit int) (list []Member, total int) {
+	g.RangeMembers(func(fd int, id string) bool {
+		list = append(list, Member{Fd: fd, Id: id})
+		return true
+	})
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Fd < list[j].Fd
+	})
+	total = len(list)
+	return page(list, offset, limit), total
 }
 
 func (g *Group) Broadcast(handle func(fd int, id string)) {
@@ -86,3 +378,17 @@ func (g *Group) RangeMembers(f func(fd int, id string) bool) {
 		return f(key.(int), value.(string))
 	})
 }
+
+func page[T any](list []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(list) {
+		return nil
+	}
+	list = list[offset:]
+	if limit > 0 && limit < len(list) {
+		list = list[:limit]
+	}
+	return list
+}
@@ -1,8 +1,133 @@
 package group
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestGroupNew(t *testing.T)       {}
 func TestGroupJoin(t *testing.T)      {}
 func TestGroupLeave(t *testing.T)     {}
 func TestGroupBroadcast(t *testing.T) {}
+
+func TestGroupListen(t *testing.T) {
+	gs := New()
+	var changes []bool
+	gs.Listen(func(name string, present bool) {
+		if name == "room" {
+			changes = append(changes, present)
+		}
+	})
+	g := gs.GetGroup("room")
+	g.Join(1, "a")
+	g.Join(1, "b")
+	g.Join(2, "c")
+	if g.MemberNum() != 2 || gs.MemberNum("room") != 2 || gs.MemberNum("none") != 0 {
+		t.Error("member num failed")
+		return
+	}
+	g.Leave(1)
+	g.Leave(1)
+	g.Leave(2)
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Error("listen failed", changes)
+	}
+}
+
+func TestGroupConfig(t *testing.T) {
+	gs := New()
+	g := gs.CreateGroup("room", Config{Meta: map[string]string{"topic": "a"}, MaxMembers: 2, AutoDelete: true})
+	if g.TryJoin(1, "a") != nil || g.TryJoin(2, "b") != nil || g.TryJoin(1, "c") != nil {
+		t.Error("join failed")
+		return
+	}
+	if g.TryJoin(3, "d") != ErrGroupFull {
+		t.Error("need group full")
+		return
+	}
+	if list, total := g.Members(1, 10); total != 2 || len(list) != 1 || list[0].Fd != 2 {
+		t.Error("members page failed", list)
+		return
+	}
+	if g.Meta()["topic"] != "a" {
+		t.Error("meta failed")
+		return
+	}
+
+	gs.CreateGroup("temp", Config{Ttl: time.Millisecond})
+	gs.GetGroup("other")
+	if list, total := gs.List("r", 0, 0); total != 1 || list[0].Name() != "room" {
+		t.Error("list failed")
+		return
+	}
+
+	g.Leave(1)
+	g.Leave(2)
+	if n := gs.Sweep(time.Now().Add(time.Second), time.Second*2); n != 1 {
+		t.Error("need sweep expired only", n)
+		return
+	}
+	if n := gs.Sweep(time.Now().Add(time.Second*3), time.Second*2); n != 1 {
+		t.Error("need sweep empty auto delete group", n)
+		return
+	}
+	if _, ok := gs.Group("room"); ok {
+		t.Error("need deleted")
+		return
+	}
+	if _, ok := gs.Group("other"); !ok {
+		t.Error("need keep group without auto delete")
+		return
+	}
+
+	g.Join(3, "c")
+	r, ok := gs.Group("room")
+	if !ok || r == g || r.MemberNum() != 1 || r.MaxMembers() != 0 {
+		t.Error("need join a new group after deleted")
+	}
+}
+
+func TestGroupDestroy(t *testing.T) {
+	gs := New()
+	var changes []bool
+	gs.Listen(func(name string, present bool) {
+		changes = append(changes, present)
+	})
+	g := gs.GetGroup("room")
+	g.Join(1, "a")
+	g.Join(2, "b")
+	g.Destroy()
+	if g.MemberNum() != 0 || len(changes) != 2 || changes[1] {
+		t.Error("need clear members on destroy", changes)
+		return
+	}
+	if err := g.TryJoin(3, "c"); err != nil {
+		t.Error(err)
+		return
+	}
+	r, ok := gs.Group("room")
+	if !ok || r == g {
+		t.Error("need create a new group")
+		return
+	}
+	if list, total := r.Members(0, 0); total != 1 || list[0].Fd != 3 {
+		t.Error("need not bring back old members", list)
+	}
+}
+
+func TestGroupSweepable(t *testing.T) {
+	gs := New()
+	var started int
+	gs.OnSweepable(func() { started++ })
+	gs.GetGroup("plain")
+	if started != 0 {
+		t.Error("need not start without ttl or auto delete")
+		return
+	}
+	gs.CreateGroup("temp", Config{Ttl: time.Minute})
+	gs.CreateGroup("auto", Config{AutoDelete: true})
+	gs.OnSweepable(func() { started++ })
+	if started != 2 {
+		t.Error("need start once per listener", started)
+	}
+}
@@ -0,0 +1,171 @@
+package ipfilter
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Filter CIDR黑白名单, 命中黑名单拒绝, 白名单非空时未命中白名单拒绝
+type Filter struct {
+	mu        sync.RWMutex
+	allow     []*net.IPNet
+	deny      []*net.IPNet
+	listeners []func()
+}
+
+func New() *Filter {
+	return &Filter{}
+}
+
+// Listen 名单变更后的回调
+func (f *Filter) Listen(fn func()) {
+	if fn != nil {
+		f.mu.Lock()
+		f.listeners = append(f.listeners, fn)
+		f.mu.Unlock()
+	}
+}
+
+// Set 替换黑白名单
+func (f *Filter) Set(allow, deny []string) error {
+	return f.update(allow, deny, func(_, add []*net.IPNet) []*net.IPNet {
+		return add
+	})
+}
+
+// Add 追加黑白名单
+func (f *Filter) Add(allow, deny []string) error {
+	return f.update(allow, deny, merge)
+}
+
+// Remove 移除黑白名单
+func (f *Filter) Remove(allow, deny []string) error {
+	return f.update(allow, deny, remove)
+}
+
+// Allows 返回白名单
+func (f *Filter) Allows() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return toStrings(f.allow)
+}
+
+// Denies 返回黑名单
+func (f *Filter) Denies() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return toStrings(f.deny)
+}
+
+// Allowed 检查ip是否允许访问
+func (f *Filter) Allowed(ip string) bool {
+	parsed := net.ParseIP(ip)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if parsed == nil {
+		return len(f.allow) == 0 && len(f.deny) == 0
+	}
+	if contains(f.deny, parsed) {
+		return false
+	}
+	return len(f.allow) == 0 || contains(f.allow, parsed)
+}
+
+func (f *Filter) update(allow, deny []string, fn func(src, nets []*net.IPNet) []*net.IPNet) error {
+	allowNets, err := parse(allow)
+	if err != nil {
+		return err
+	}
+	denyNets, err := parse(deny)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.allow = fn(f.allow, allowNets)
+	f.deny = fn(f.deny, denyNets)
+	f.mu.Unlock()
+	f.changed()
+	return nil
+}
+
+func (f *Filter) changed() {
+	f.mu.RLock()
+	listeners := f.listeners
+	f.mu.RUnlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+func parse(cidrs []string) (nets []*net.IPNet, err error) {
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, errors.New("invalid ip: " + cidr)
+			}
+			if ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, n, err1 := net.ParseCIDR(cidr)
+		if err1 != nil {
+			return nil, errors.New("invalid cidr: " + cidr)
+		}
+		nets = append(nets, n)
+	}
+	return
+}
+
+func merge(src, add []*net.IPNet) []*net.IPNet {
+	for _, n := range add {
+		if indexOf(src, n) < 0 {
+			src = append(src, n)
+		}
+	}
+	return src
+}
+
+func remove(src, del []*net.IPNet) []*net.IPNet {
+	var list []*net.IPNet
+	for _, n := range src {
+		if indexOf(del, n) < 0 {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+func indexOf(nets []*net.IPNet, n *net.IPNet) int {
+	for i, item := range nets {
+		if item.String() == n.String() {
+			return i
+		}
+	}
+	return -1
+}
+
+func contains(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func toStrings(nets []*net.IPNet) []string {
+	list := make([]string, 0, len(nets))
+	for _, n := range nets {
+		list = append(list, n.String())
+	}
+	return list
+}
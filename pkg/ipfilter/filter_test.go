@@ -0,0 +1,38 @@
+package ipfilter
+
+import "testing"
+
+func TestFilter(t *testing.T) {
+	f := New()
+	if !f.Allowed("10.0.0.1") {
+		t.Error("empty filter need allow")
+		return
+	}
+	if err := f.Set(nil, []string{"10.0.0.0/8", "192.168.1.1"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if f.Allowed("10.1.2.3") || f.Allowed("192.168.1.1") {
+		t.Error("need deny but allowed")
+		return
+	}
+	if !f.Allowed("192.168.1.2") {
+		t.Error("need allow but denied")
+		return
+	}
+	if err := f.Add([]string{"172.16.0.0/12"}, nil); err != nil {
+		t.Error(err)
+		return
+	}
+	if f.Allowed("192.168.1.2") || !f.Allowed("172.16.5.5") {
+		t.Error("allow list not effected")
+		return
+	}
+	if err := f.Remove(nil, []string{"192.168.1.1/32"}); err != nil || len(f.Denies()) != 1 {
+		t.Error("remove deny failed")
+		return
+	}
+	if err := f.Add(nil, []string{"bad"}); err == nil || len(f.Denies()) != 1 {
+		t.Error("invalid cidr need error")
+	}
+}
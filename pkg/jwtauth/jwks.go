@@ -0,0 +1,176 @@
+package jwtauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// jwk JWKS中的单个key
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type key struct {
+	kid string
+	alg string
+	kty string
+	pub interface{} // []byte, *rsa.PublicKey, *ecdsa.PublicKey
+}
+
+// keySet 从本地JWKS文件加载的key, 文件修改后自动重新加载
+type keySet struct {
+	path      string
+	interval  time.Duration
+	mu        sync.RWMutex
+	keys      []key
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+func newKeySet(path string, interval time.Duration) (*keySet, error) {
+	ks := &keySet{
+		path:     path,
+		interval: interval,
+	}
+	if err := ks.Reload(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Reload 重新加载JWKS文件
+func (ks *keySet) Reload() error {
+	info, err := os.Stat(ks.path)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(ks.path)
+	if err != nil {
+		return err
+	}
+	keys, err := parseJwks(b)
+	if err != nil {
+		return err
+	}
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.modTime = info.ModTime()
+	ks.checkedAt = time.Now()
+	ks.mu.Unlock()
+	return nil
+}
+
+// refresh 超过检查间隔时检查文件是否修改
+func (ks *keySet) refresh() {
+	ks.mu.RLock()
+	due := time.Since(ks.checkedAt) >= ks.interval
+	modTime := ks.modTime
+	ks.mu.RUnlock()
+	if !due {
+		return
+	}
+	ks.mu.Lock()
+	ks.checkedAt = time.Now()
+	ks.mu.Unlock()
+	if info, err := os.Stat(ks.path); err == nil && !info.ModTime().Equal(modTime) {
+		_ = ks.Reload()
+	}
+}
+
+// find 根据kid和alg返回候选key
+func (ks *keySet) find(kid, alg string) (list []key) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	for _, k := range ks.keys {
+		if kid != "" && k.kid != kid {
+			continue
+		}
+		if k.alg != "" && k.alg != alg {
+			continue
+		}
+		if k.kty != ktyOf(alg) {
+			continue
+		}
+		list = append(list, k)
+	}
+	return
+}
+
+func parseJwks(b []byte) (keys []key, err error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.Unmarshal(b, &set); err != nil {
+		return nil, errors.New("jwks: invalid json, err=" + err.Error())
+	}
+	for _, j := range set.Keys {
+		if j.Use != "" && j.Use != "sig" {
+			continue
+		}
+		k := key{kid: j.Kid, alg: j.Alg, kty: j.Kty}
+		switch j.Kty {
+		case "oct":
+			if k.pub, err = decodeSegment(j.K); err != nil {
+				return nil, errors.New("jwks: invalid oct key " + j.Kid)
+			}
+		case "RSA":
+			n, err1 := decodeBigInt(j.N)
+			e, err2 := decodeBigInt(j.E)
+			if err1 != nil || err2 != nil {
+				return nil, errors.New("jwks: invalid rsa key " + j.Kid)
+			}
+			k.pub = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			var curve elliptic.Curve
+			switch j.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			case "P-521":
+				curve = elliptic.P521()
+			default:
+				return nil, errors.New("jwks: unsupported curve " + j.Crv)
+			}
+			x, err1 := decodeBigInt(j.X)
+			y, err2 := decodeBigInt(j.Y)
+			if err1 != nil || err2 != nil {
+				return nil, errors.New("jwks: invalid ec key " + j.Kid)
+			}
+			k.pub = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		default:
+			continue
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := decodeSegment(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
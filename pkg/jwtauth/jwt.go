@@ -0,0 +1,151 @@
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrMalformed        error = invalidToken("jwt: malformed token")
+	ErrUnsupportedAlg   error = invalidToken("jwt: unsupported algorithm")
+	ErrNoKey                  = errors.New("jwt: no matched key") // 可能是密钥尚未热加载, 不算确定的无效
+	ErrInvalidSignature error = invalidToken("jwt: invalid signature")
+)
+
+// invalidToken token本身无效的错误, 重试不会成功, 缓存可以记录失败结果
+type invalidToken string
+
+func (e invalidToken) Error() string {
+	return string(e)
+}
+
+func (e invalidToken) InvalidToken() bool {
+	return true
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Claims jwt的payload
+type Claims map[string]interface{}
+
+func ktyOf(alg string) string {
+	switch {
+	case strings.HasPrefix(alg, "HS"):
+		return "oct"
+	case strings.HasPrefix(alg, "RS"):
+		return "RSA"
+	case strings.HasPrefix(alg, "ES"):
+		return "EC"
+	}
+	return ""
+}
+
+func hashOf(alg string) (crypto.Hash, bool) {
+	if len(alg) != 5 {
+		return 0, false
+	}
+	switch alg[2:] {
+	case "256":
+		return crypto.SHA256, true
+	case "384":
+		return crypto.SHA384, true
+	case "512":
+		return crypto.SHA512, true
+	}
+	return 0, false
+}
+
+// parse 解析并验证token签名, 返回claims
+func parse(token string, ks *keySet, algorithms []string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+	hb, err := decodeSegment(parts[0])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	var h header
+	if err = json.Unmarshal(hb, &h); err != nil {
+		return nil, ErrMalformed
+	}
+	hash, ok := hashOf(h.Alg)
+	if !ok || ktyOf(h.Alg) == "" || (len(algorithms) > 0 && !inStrings(algorithms, h.Alg)) {
+		return nil, ErrUnsupportedAlg
+	}
+	sig, err := decodeSegment(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	keys := ks.find(h.Kid, h.Alg)
+	if len(keys) == 0 {
+		return nil, ErrNoKey
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, k := range keys {
+		if verify(k, hash, signed, sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrInvalidSignature
+	}
+	pb, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	claims := Claims{}
+	dec := json.NewDecoder(strings.NewReader(string(pb)))
+	dec.UseNumber()
+	if err = dec.Decode(&claims); err != nil {
+		return nil, ErrMalformed
+	}
+	return claims, nil
+}
+
+func verify(k key, hash crypto.Hash, signed, sig []byte) bool {
+	switch pub := k.pub.(type) {
+	case []byte:
+		mac := hmac.New(hash.New, pub)
+		mac.Write(signed)
+		return hmac.Equal(sig, mac.Sum(nil))
+	case *rsa.PublicKey:
+		h := hash.New()
+		h.Write(signed)
+		return rsa.VerifyPKCS1v15(pub, hash, h.Sum(nil), sig) == nil
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false
+		}
+		h := hash.New()
+		h.Write(signed)
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(pub, h.Sum(nil), r, s)
+	}
+	return false
+}
+
+func inStrings(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
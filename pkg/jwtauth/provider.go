@@ -0,0 +1,253 @@
+package jwtauth
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrExpired         error = invalidToken("jwt: token expired")
+	ErrNotValidYet           = errors.New("jwt: token not valid yet") // 时钟偏差时稍后即有效
+	ErrInvalidAudience error = invalidToken("jwt: invalid audience")
+	ErrInvalidIssuer   error = invalidToken("jwt: invalid issuer")
+	ErrNoUserId        error = invalidToken("jwt: user id claim missing")
+)
+
+// Mapping claim到AuthUser的映射
+type Mapping struct {
+	Id    string            // AuthUser.Id, 默认sub
+	Name  string            // AuthUser.Name, 默认name
+	Attrs map[string]string // AuthUser.Attr的key => claim, 默认映射user_id、company_id、organization_id
+}
+
+// Provider 基于本地JWKS验证jwt的AuthProvider
+type Provider struct {
+	keys           *keySet
+	audiences      []string
+	issuers        []string
+	algorithms     []string
+	leeway         time.Duration
+	mapping        Mapping
+	reloadInterval time.Duration
+	idUser         func(uid string) (*socket.AuthUser, error)
+	now            func() time.Time
+}
+
+type Option func(*Provider)
+
+// Audience 允许的aud, 为空不校验
+func Audience(aud ...string) Option {
+	return func(p *Provider) {
+		p.audiences = append(p.audiences, aud...)
+	}
+}
+
+// Issuer 允许的iss, 为空不校验
+func Issuer(iss ...string) Option {
+	return func(p *Provider) {
+		p.issuers = append(p.issuers, iss...)
+	}
+}
+
+// Algorithms 允许的签名算法, 为空时允许HS/RS/ES的256、384、512
+func Algorithms(alg ...string) Option {
+	return func(p *Provider) {
+		p.algorithms = append(p.algorithms, alg...)
+	}
+}
+
+// Leeway exp、nbf校验允许的时钟偏差
+func Leeway(d time.Duration) Option {
+	return func(p *Provider) {
+		p.leeway = d
+	}
+}
+
+// ClaimMapping claim到AuthUser的映射
+func ClaimMapping(m Mapping) Option {
+	return func(p *Provider) {
+		if m.Id != "" {
+			p.mapping.Id = m.Id
+		}
+		if m.Name != "" {
+			p.mapping.Name = m.Name
+		}
+		for attr, claim := range m.Attrs {
+			p.mapping.Attrs[attr] = claim
+		}
+	}
+}
+
+// ReloadInterval JWKS文件修改检查间隔, 默认10秒
+func ReloadInterval(d time.Duration) Option {
+	return func(p *Provider) {
+		p.reloadInterval = d
+	}
+}
+
+// IdUser 非user类型authenticate时根据uid获取用户, 默认仅返回uid
+func IdUser(fn func(uid string) (*socket.AuthUser, error)) Option {
+	return func(p *Provider) {
+		p.idUser = fn
+	}
+}
+
+// New 从本地JWKS文件创建Provider
+func New(jwksPath string, options ...Option) (*Provider, error) {
+	p := &Provider{
+		mapping: Mapping{
+			Id:   "sub",
+			Name: "name",
+			Attrs: map[string]string{
+				"user_id":         "user_id",
+				"company_id":      "company_id",
+				"organization_id": "organization_id",
+			},
+		},
+		reloadInterval: time.Second * 10,
+		now:            time.Now,
+	}
+	for _, o := range options {
+		o(p)
+	}
+	ks, err := newKeySet(jwksPath, p.reloadInterval)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = ks
+	return p, nil
+}
+
+// Reload 立即重新加载JWKS文件
+func (p *Provider) Reload() error {
+	return p.keys.Reload()
+}
+
+func (p *Provider) GetAuthedUser(token string) (*socket.AuthUser, error) {
+	u, _, err := p.GetAuthedUserWithExpire(token)
+	return u, err
+}
+
+func (p *Provider) GetAuthedUserWithExpire(token string) (u *socket.AuthUser, expireAt time.Time, err error) {
+	claims, err := p.Verify(token)
+	if err != nil {
+		return
+	}
+	if exp, ok := claimTime(claims, "exp"); ok {
+		expireAt = exp
+	}
+	u, err = p.toUser(claims)
+	return
+}
+
+func (p *Provider) GetIdUser(uid string) (*socket.AuthUser, error) {
+	if p.idUser != nil {
+		return p.idUser(uid)
+	}
+	id, _ := strconv.Atoi(uid)
+	return &socket.AuthUser{
+		Id:   uint(id),
+		Attr: map[string]string{"user_id": uid},
+	}, nil
+}
+
+// Verify 验证token签名及exp、nbf、aud、iss, token可带 appid 或 Bearer 前缀
+func (p *Provider) Verify(token string) (Claims, error) {
+	if i := strings.LastIndex(token, " "); i >= 0 {
+		token = token[i+1:]
+	}
+	p.keys.refresh()
+	claims, err := parse(token, p.keys, p.algorithms)
+	if err != nil {
+		return nil, err
+	}
+	now := p.now()
+	if exp, ok := claimTime(claims, "exp"); ok && !now.Before(exp.Add(p.leeway)) {
+		return nil, ErrExpired
+	}
+	if nbf, ok := claimTime(claims, "nbf"); ok && now.Add(p.leeway).Before(nbf) {
+		return nil, ErrNotValidYet
+	}
+	if len(p.audiences) > 0 {
+		matched := false
+		for _, aud := range claimStrings(claims, "aud") {
+			if inStrings(p.audiences, aud) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, ErrInvalidAudience
+		}
+	}
+	if len(p.issuers) > 0 && !inStrings(p.issuers, claimString(claims, "iss")) {
+		return nil, ErrInvalidIssuer
+	}
+	return claims, nil
+}
+
+func (p *Provider) toUser(claims Claims) (*socket.AuthUser, error) {
+	idStr := claimString(claims, p.mapping.Id)
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if idStr == "" || err != nil {
+		return nil, ErrNoUserId
+	}
+	u := &socket.AuthUser{
+		Id:   uint(id),
+		Name: claimString(claims, p.mapping.Name),
+		Attr: make(map[string]string),
+	}
+	for attr, claim := range p.mapping.Attrs {
+		if v := claimString(claims, claim); v != "" {
+			u.Attr[attr] = v
+		}
+	}
+	if _, ok := u.Attr["user_id"]; !ok {
+		u.Attr["user_id"] = idStr
+	}
+	return u, nil
+}
+
+func claimString(claims Claims, name string) string {
+	switch v := claims[name].(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+func claimStrings(claims Claims, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func claimTime(claims Claims, name string) (time.Time, bool) {
+	v, ok := claims[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := v.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(f*float64(time.Second))), true
+}
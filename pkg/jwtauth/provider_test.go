@@ -0,0 +1,109 @@
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func sign(t *testing.T, alg, kid string, claims map[string]interface{}, k interface{}) string {
+	hb, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	pb, _ := json.Marshal(claims)
+	signed := b64(hb) + "." + b64(pb)
+	digest := sha256.Sum256([]byte(signed))
+	var sig []byte
+	switch key := k.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return signed + "." + b64(sig)
+}
+
+func TestProvider(t *testing.T) {
+	hs := []byte("secret-key")
+	rk, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ek, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "oct", "kid": "hs", "k": b64(hs)},
+			{"kty": "RSA", "kid": "rs", "n": b64(rk.N.Bytes()), "e": b64(big.NewInt(int64(rk.E)).Bytes())},
+			{"kty": "EC", "kid": "es", "crv": "P-256", "x": b64(ek.X.Bytes()), "y": b64(ek.Y.Bytes())},
+		},
+	}
+	b, _ := json.Marshal(jwks)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := New(path, Audience("gateway"), Issuer("uc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+	claims := map[string]interface{}{"sub": "12", "name": "tom", "company_id": 3, "aud": []string{"gateway"}, "iss": "uc", "exp": exp}
+
+	for _, c := range []struct {
+		alg, kid string
+		key      interface{}
+	}{{"HS256", "hs", hs}, {"RS256", "rs", rk}, {"ES256", "es", ek}} {
+		u, expireAt, err1 := p.GetAuthedUserWithExpire("app " + sign(t, c.alg, c.kid, claims, c.key))
+		if err1 != nil {
+			t.Error(c.alg, err1)
+			return
+		}
+		if u.Id != 12 || u.Name != "tom" || u.Cid() != 3 || u.Uno() != "12" || expireAt.Unix() != exp {
+			t.Error(c.alg, "claim mapping failed")
+			return
+		}
+	}
+
+	if _, err = p.GetAuthedUser(sign(t, "RS256", "hs", claims, rk)); err != ErrNoKey {
+		t.Error("need no key error but", err)
+		return
+	}
+	token := sign(t, "HS256", "hs", claims, hs)
+	if _, err = p.GetAuthedUser(token[:len(token)-2] + "xx"); err != ErrInvalidSignature {
+		t.Error("need invalid signature but", err)
+		return
+	}
+	claims["exp"] = time.Now().Add(-time.Minute).Unix()
+	if _, err = p.GetAuthedUser(sign(t, "HS256", "hs", claims, hs)); err != ErrExpired {
+		t.Error("need expired but", err)
+		return
+	}
+	claims["exp"] = exp
+	claims["aud"] = "other"
+	if _, err = p.GetAuthedUser(sign(t, "HS256", "hs", claims, hs)); err != ErrInvalidAudience {
+		t.Error("need invalid audience but", err)
+	}
+}
@@ -1,10 +1,16 @@
 package mqtt
 
 import (
+	"errors"
 	"github.com/obnahsgnaw/goutils/strutil"
 	"regexp"
+	"strings"
 )
 
+var ErrInvalidTopicFilter = errors.New("invalid topic filter")
+
+var paramLevel = regexp.MustCompile(`^{(\w+)}$`)
+
 // ParamPattern parse {param} in regexp pattern
 type ParamPattern struct {
 	re *regexp.Regexp
@@ -19,6 +25,54 @@ func NewParamPattern(pattern string) (*ParamPattern, error) {
 	return &ParamPattern{re: re}, nil
 }
 
+// NewTopicPattern 解析mqtt主题过滤器, +匹配单层, #匹配多层且只能在末尾, {param}匹配单层并可通过Parse取出
+func NewTopicPattern(filter string) (*ParamPattern, error) {
+	if filter == "" {
+		return nil, ErrInvalidTopicFilter
+	}
+	levels := strings.Split(filter, "/")
+	var b strings.Builder
+	b.WriteString("^")
+	for i, level := range levels {
+		sep := ""
+		if i > 0 {
+			sep = "/"
+		}
+		switch {
+		case level == "#":
+			if i != len(levels)-1 {
+				return nil, ErrInvalidTopicFilter
+			}
+			// a/#同时匹配a
+			if i == 0 {
+				b.WriteString(".*")
+			} else {
+				b.WriteString("(?:/.*)?")
+			}
+		case level == "+":
+			b.WriteString(sep + "[^/]*")
+		case paramLevel.MatchString(level):
+			b.WriteString(sep + "(?P<" + level[1:len(level)-1] + ">[^/]+)")
+		default:
+			if strings.ContainsAny(level, "+#{}") {
+				return nil, ErrInvalidTopicFilter
+			}
+			b.WriteString(sep + regexp.QuoteMeta(level))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, ErrInvalidTopicFilter
+	}
+	return &ParamPattern{re: re}, nil
+}
+
+// Match 是否匹配
+func (p *ParamPattern) Match(str string) bool {
+	return p.re.MatchString(str)
+}
+
 // Parse the str params
 func (p *ParamPattern) Parse(str string) map[string]string {
 	result := make(map[string]string)
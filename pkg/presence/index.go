@@ -0,0 +1,112 @@
+package presence
+
+import (
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"sort"
+	"sync"
+)
+
+// GroupType 组在索引中的id类型, 组在网关上有成员即视为该网关持有该组
+const GroupType = "@group"
+
+// GroupId 返回组在索引中的id
+func GroupId(name string) socket.ConnId {
+	return socket.ConnId{Type: GroupType, Id: name}
+}
+
+// Index 集群在线索引, 记录各网关持有的绑定id
+type Index struct {
+	host  string
+	local func(id socket.ConnId) bool
+	mu    sync.RWMutex
+	hosts map[string]map[socket.ConnId]struct{} // host => ids
+	ids   map[socket.ConnId]map[string]struct{} // id => hosts
+}
+
+// New host为当前网关的地址, local用于查询当前网关是否持有id
+func New(host string, local func(id socket.ConnId) bool) *Index {
+	return &Index{
+		host:  host,
+		local: local,
+		hosts: make(map[string]map[socket.ConnId]struct{}),
+		ids:   make(map[socket.ConnId]map[string]struct{}),
+	}
+}
+
+// Host 返回当前网关的地址
+func (i *Index) Host() string {
+	return i.host
+}
+
+// Apply 应用其他网关同步的id, full为true时先清除该网关的全部id
+func (i *Index) Apply(host string, full bool, added, removed []socket.ConnId) {
+	if host == "" || host == i.host {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if full {
+		i.drop(host)
+	}
+	for _, id := range removed {
+		i.remove(host, id)
+	}
+	for _, id := range added {
+		if _, ok := i.hosts[host]; !ok {
+			i.hosts[host] = make(map[socket.ConnId]struct{})
+		}
+		i.hosts[host][id] = struct{}{}
+		if _, ok := i.ids[id]; !ok {
+			i.ids[id] = make(map[string]struct{})
+		}
+		i.ids[id][host] = struct{}{}
+	}
+}
+
+// Drop 清除网关的全部id, 用于网关下线
+func (i *Index) Drop(host string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.drop(host)
+}
+
+func (i *Index) drop(host string) {
+	for id := range i.hosts[host] {
+		i.remove(host, id)
+	}
+	delete(i.hosts, host)
+}
+
+func (i *Index) remove(host string, id socket.ConnId) {
+	if ids, ok := i.hosts[host]; ok {
+		delete(ids, id)
+	}
+	if hosts, ok := i.ids[id]; ok {
+		delete(hosts, host)
+		if len(hosts) == 0 {
+			delete(i.ids, id)
+		}
+	}
+}
+
+// Locate 返回持有id的网关地址, 当前网关排在最前
+func (i *Index) Locate(id socket.ConnId) (hosts []string) {
+	if i.local != nil && i.local(id) {
+		hosts = append(hosts, i.host)
+	}
+	i.mu.RLock()
+	var remote []string
+	for host := range i.ids[id] {
+		remote = append(remote, host)
+	}
+	i.mu.RUnlock()
+	sort.Strings(remote)
+	return append(hosts, remote...)
+}
+
+// Count 返回网关同步的id数量
+func (i *Index) Count(host string) int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.hosts[host])
+}
@@ -0,0 +1,138 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIndex(t *testing.T) {
+	d1 := socket.ConnId{Type: "TARGET", Id: "d1"}
+	d2 := socket.ConnId{Type: "TARGET", Id: "d2"}
+	idx := New("127.0.0.1:1", func(id socket.ConnId) bool {
+		return id == d1
+	})
+
+	idx.Apply("127.0.0.1:3", false, []socket.ConnId{d1, d2}, nil)
+	idx.Apply("127.0.0.1:2", false, []socket.ConnId{d1}, nil)
+	if hosts := idx.Locate(d1); len(hosts) != 3 || hosts[0] != "127.0.0.1:1" || hosts[1] != "127.0.0.1:2" {
+		t.Error("locate failed", hosts)
+		return
+	}
+
+	idx.Apply("127.0.0.1:3", false, nil, []socket.ConnId{d2})
+	if hosts := idx.Locate(d2); len(hosts) != 0 {
+		t.Error("removed need not locate", hosts)
+		return
+	}
+
+	idx.Apply("127.0.0.1:2", true, []socket.ConnId{d2}, nil)
+	if hosts := idx.Locate(d2); len(hosts) != 1 || hosts[0] != "127.0.0.1:2" || idx.Count("127.0.0.1:2") != 1 {
+		t.Error("full apply failed", hosts)
+		return
+	}
+
+	idx.Drop("127.0.0.1:3")
+	if hosts := idx.Locate(d1); len(hosts) != 1 {
+		t.Error("drop failed", hosts)
+	}
+}
+
+func TestPublisher(t *testing.T) {
+	d1 := socket.ConnId{Type: "TARGET", Id: "d1"}
+	d2 := socket.ConnId{Type: "UID", Id: "2"}
+	fail := true
+	type call struct {
+		full           bool
+		added, removed int
+	}
+	var mu sync.Mutex
+	calls := make(map[string][]call)
+	present := map[socket.ConnId]bool{d1: true}
+	p := NewPublisher(func() []socket.ConnId {
+		return []socket.ConnId{d1}
+	}, func(id socket.ConnId) bool {
+		return present[id]
+	}, func(_ context.Context, host string, full bool, added, removed []socket.ConnId) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[host] = append(calls[host], call{full, len(added), len(removed)})
+		if host == "b" && fail {
+			return errors.New("unavailable")
+		}
+		return nil
+	}, 0)
+
+	p.AddPeer("a")
+	p.AddPeer("b")
+	p.Flush(context.Background())
+	if len(calls["a"]) != 1 || !calls["a"][0].full || calls["a"][0].added != 1 {
+		t.Error("need full sync to new peer")
+		return
+	}
+
+	present = map[socket.ConnId]bool{d2: true}
+	p.Changed(d2, true)
+	p.Changed(d1, false)
+	fail = false
+	p.Flush(context.Background())
+	if c := calls["a"][1]; c.full || c.added != 1 || c.removed != 1 {
+		t.Error("need delta sync", c)
+		return
+	}
+	if c := calls["b"][1]; !c.full {
+		t.Error("need full sync after failed", c)
+		return
+	}
+
+	p.Flush(context.Background())
+	if len(calls["a"]) != 2 || len(calls["b"]) != 2 {
+		t.Error("need not sync without change")
+	}
+}
+
+func TestPublisherChunkAndTimeout(t *testing.T) {
+	var ids []socket.ConnId
+	for i := 0; i < 25; i++ {
+		ids = append(ids, socket.ConnId{Type: "TARGET", Id: strconv.Itoa(i)})
+	}
+	idx := New("a", nil)
+	var mu sync.Mutex
+	p := NewPublisher(func() []socket.ConnId {
+		return ids
+	}, func(id socket.ConnId) bool {
+		return true
+	}, func(ctx context.Context, host string, full bool, added, removed []socket.ConnId) error {
+		if host == "slow" {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		if len(added) > 10 {
+			return errors.New("chunk too large")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		idx.Apply("self", full, added, removed)
+		return nil
+	}, 0)
+	p.SetChunk(10)
+	p.SetTimeout(50 * time.Millisecond)
+	p.AddPeer("slow")
+	p.AddPeer("a")
+
+	start := time.Now()
+	p.Flush(context.Background())
+	if d := time.Since(start); d > time.Second {
+		t.Error("slow peer need not stall flush", d)
+	}
+	if n := idx.Count("self"); n != len(ids) {
+		t.Error("need all ids synced in chunks", n)
+	}
+	if !p.peers["slow"] || p.peers["a"] {
+		t.Error("need full resync only for the timed out peer")
+	}
+}
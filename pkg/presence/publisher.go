@@ -0,0 +1,170 @@
+package presence
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"sync"
+	"time"
+)
+
+// SendFunc 向网关host同步id
+type SendFunc func(ctx context.Context, host string, full bool, added, removed []socket.ConnId) error
+
+const (
+	// DefaultChunk 全量同步时单次发送的id数量上限, 避免超出grpc消息大小限制
+	DefaultChunk = 10000
+	// DefaultTimeout 向单个网关同步的超时时间
+	DefaultTimeout = time.Second * 3
+)
+
+// Publisher 汇总当前网关id的变化, 定时同步给其他网关, 新网关及同步失败的网关会收到全量
+type Publisher struct {
+	mu       sync.Mutex
+	pending  map[socket.ConnId]struct{}
+	peers    map[string]bool // host => need full
+	snapshot func() []socket.ConnId
+	local    func(id socket.ConnId) bool
+	send     SendFunc
+	interval time.Duration
+	chunk    int
+	timeout  time.Duration
+}
+
+// NewPublisher snapshot返回当前网关的全部id, local查询当前网关是否持有id, interval为同步间隔
+func NewPublisher(snapshot func() []socket.ConnId, local func(id socket.ConnId) bool, send SendFunc, interval time.Duration) *Publisher {
+	if interval <= 0 {
+		interval = time.Millisecond * 200
+	}
+	return &Publisher{
+		pending:  make(map[socket.ConnId]struct{}),
+		peers:    make(map[string]bool),
+		snapshot: snapshot,
+		local:    local,
+		send:     send,
+		interval: interval,
+		chunk:    DefaultChunk,
+		timeout:  DefaultTimeout,
+	}
+}
+
+// SetChunk 设置全量同步单次发送的id数量上限
+func (p *Publisher) SetChunk(n int) {
+	if n > 0 {
+		p.chunk = n
+	}
+}
+
+// SetTimeout 设置向单个网关同步的超时时间
+func (p *Publisher) SetTimeout(d time.Duration) {
+	if d > 0 {
+		p.timeout = d
+	}
+}
+
+// Changed 记录id在当前网关的出现与消失, 同步时以local的结果为准, 避免并发变化的乱序
+func (p *Publisher) Changed(id socket.ConnId, _ bool) {
+	p.mu.Lock()
+	p.pending[id] = struct{}{}
+	p.mu.Unlock()
+}
+
+// AddPeer 添加网关, 下次同步时发送全量
+func (p *Publisher) AddPeer(host string) {
+	p.mu.Lock()
+	p.peers[host] = true
+	p.mu.Unlock()
+}
+
+// RemovePeer 移除网关
+func (p *Publisher) RemovePeer(host string) {
+	p.mu.Lock()
+	delete(p.peers, host)
+	p.mu.Unlock()
+}
+
+// Flush 立即同步, 并发发送给各网关, 单个网关超时不影响其他网关
+func (p *Publisher) Flush(ctx context.Context) {
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = make(map[socket.ConnId]struct{})
+	peers := make(map[string]bool, len(p.peers))
+	for host, full := range p.peers {
+		peers[host] = full
+		p.peers[host] = false
+	}
+	p.mu.Unlock()
+
+	var added, removed []socket.ConnId
+	for id := range pending {
+		if p.local(id) {
+			added = append(added, id)
+		} else {
+			removed = append(removed, id)
+		}
+	}
+	var all []socket.ConnId
+	for _, full := range peers {
+		if full {
+			all = p.snapshot()
+			break
+		}
+	}
+	var wg sync.WaitGroup
+	for host, full := range peers {
+		if !full && len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(host string, full bool) {
+			defer wg.Done()
+			ctx1, cancel := context.WithTimeout(ctx, p.timeout)
+			defer cancel()
+			var err error
+			if full {
+				err = p.sendFull(ctx1, host, all)
+			} else {
+				err = p.send(ctx1, host, false, added, removed)
+			}
+			if err != nil {
+				p.mu.Lock()
+				if _, ok := p.peers[host]; ok {
+					p.peers[host] = true
+				}
+				p.mu.Unlock()
+			}
+		}(host, full)
+	}
+	wg.Wait()
+}
+
+// sendFull 分块发送全量, 首块标记为全量以清除对方的旧数据, 后续块为增量
+func (p *Publisher) sendFull(ctx context.Context, host string, all []socket.ConnId) error {
+	full := true
+	for {
+		n := len(all)
+		if n > p.chunk {
+			n = p.chunk
+		}
+		if err := p.send(ctx, host, full, all[:n], nil); err != nil {
+			return err
+		}
+		all, full = all[n:], false
+		if len(all) == 0 {
+			return nil
+		}
+	}
+}
+
+// Run 定时同步直到ctx结束
+func (p *Publisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Flush(ctx)
+		}
+	}
+}
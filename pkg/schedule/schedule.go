@@ -0,0 +1,355 @@
+package schedule
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/goutils/randutil"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrNotStarted = errors.New("schedule: scheduler not started")
+
+// 默认的投递并发数与重试策略
+const (
+	DefaultWorkers     = 16
+	DefaultMaxAttempts = 5
+	DefaultBackoff     = time.Second
+	DefaultMaxBackoff  = time.Minute
+)
+
+// permanentError 不再重试的投递失败
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 标记投递失败无需重试(如参数错误), 任务直接删除
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent 返回投递失败是否无需重试
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// Job 定时投递的消息, Fd与绑定id二选一
+// fd会被新连接复用, 以ConnectedAt识别创建时的连接, 连接断开或网关重启后不再投递
+type Job struct {
+	Id          string
+	At          time.Time // 投递时间
+	Fd          int
+	ConnectedAt time.Time // Fd连接的建立时间
+	Type        string    // 绑定id类型
+	TargetId    string    // 绑定id
+	ActionId    uint32
+	ActionName  string
+	PbMessage   []byte
+	JsonMessage []byte
+	Cid         uint32 // 创建者声明的公司id, 用于多租户隔离
+	CreatedAt   time.Time
+	Attempts    int // 已失败的投递次数
+}
+
+type Option func(s *Scheduler)
+
+// Workers 同时投递的最大任务数
+func Workers(n int) Option {
+	return func(s *Scheduler) {
+		if n > 0 {
+			s.workers = n
+		}
+	}
+}
+
+// Retry 投递失败后按指数退避重试, 共尝试maxAttempts次后删除任务
+func Retry(maxAttempts int, backoff, maxBackoff time.Duration) Option {
+	return func(s *Scheduler) {
+		if maxAttempts > 0 {
+			s.maxAttempts = maxAttempts
+		}
+		if backoff > 0 {
+			s.backoff = backoff
+		}
+		if maxBackoff >= s.backoff {
+			s.maxBackoff = maxBackoff
+		}
+	}
+}
+
+// Scheduler 按投递时间派发定时任务, 任务先持久化再调度, 投递成功或放弃重试后删除
+type Scheduler struct {
+	store       Store
+	deliver     func(j Job) error
+	mu          sync.Mutex
+	jobs        map[string]*item
+	queue       queue
+	wake        chan struct{}
+	started     bool
+	workers     int
+	busy        int
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+}
+
+func New(store Store, o ...Option) *Scheduler {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	s := &Scheduler{
+		store:       store,
+		jobs:        make(map[string]*item),
+		wake:        make(chan struct{}, 1),
+		workers:     DefaultWorkers,
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultBackoff,
+		maxBackoff:  DefaultMaxBackoff,
+	}
+	for _, oo := range o {
+		if oo != nil {
+			oo(s)
+		}
+	}
+	return s
+}
+
+// Start 加载已持久化的任务并开始调度, 到期的任务交由deliver投递, 直到ctx结束
+// deliver返回错误时按重试策略重新调度, 返回Permanent错误时不再重试
+func (s *Scheduler) Start(ctx context.Context, deliver func(j Job) error) error {
+	list, err := s.store.List()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.deliver = deliver
+	for _, j := range list {
+		s.push(j)
+	}
+	s.started = true
+	s.mu.Unlock()
+	go s.run(ctx)
+	return nil
+}
+
+// Add 添加任务, Id为空时生成, 返回添加的任务
+func (s *Scheduler) Add(j Job) (Job, error) {
+	if j.Id == "" {
+		j.Id = randutil.RandAlphaNum(16)
+	}
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = time.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.started {
+		return Job{}, ErrNotStarted
+	}
+	if err := s.store.Save(j); err != nil {
+		return Job{}, err
+	}
+	if old, ok := s.jobs[j.Id]; ok && old.index >= 0 {
+		heap.Remove(&s.queue, old.index)
+	}
+	s.push(j)
+	s.notify()
+	return j, nil
+}
+
+// Cancel 取消任务, 返回是否存在
+func (s *Scheduler) Cancel(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.jobs[id]
+	if !ok {
+		return false, nil
+	}
+	if err := s.store.Delete(id); err != nil {
+		return false, err
+	}
+	// 投递中的任务完成后不再处理
+	if it.index >= 0 {
+		heap.Remove(&s.queue, it.index)
+	}
+	delete(s.jobs, id)
+	s.notify()
+	return true, nil
+}
+
+// Get 返回任务
+func (s *Scheduler) Get(id string) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if it, ok := s.jobs[id]; ok {
+		return it.job, true
+	}
+	return Job{}, false
+}
+
+// List 按投递时间分页返回满足filter的任务, filter为nil时返回全部, limit<=0时不分页
+func (s *Scheduler) List(filter func(j Job) bool, offset, limit int) (list []Job, total int) {
+	s.mu.Lock()
+	for _, it := range s.jobs {
+		if filter == nil || filter(it.job) {
+			list = append(list, it.job)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].At.Equal(list[j].At) {
+			return list[i].Id < list[j].Id
+		}
+		return list[i].At.Before(list[j].At)
+	})
+	total = len(list)
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(list) {
+		return nil, total
+	}
+	list = list[offset:]
+	if limit > 0 && limit < len(list) {
+		list = list[:limit]
+	}
+	return list, total
+}
+
+func (s *Scheduler) push(j Job) {
+	it := &item{job: j}
+	heap.Push(&s.queue, it)
+	s.jobs[j.Id] = it
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		due, next := s.due(time.Now())
+		for _, it := range due {
+			go s.exec(it)
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(next)
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// due 取出到期且有空闲投递协程的任务, 返回距下一次检查的时间
+// 取出的任务仍保留在jobs与存储中, 投递成功后才删除
+func (s *Scheduler) due(now time.Time) (list []*item, next time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.queue.Len() > 0 {
+		it := s.queue[0]
+		if it.job.At.After(now) {
+			return list, it.job.At.Sub(now)
+		}
+		if s.busy >= s.workers {
+			// 等待投递协程空闲时唤醒
+			return list, time.Hour
+		}
+		heap.Pop(&s.queue)
+		s.busy++
+		list = append(list, it)
+	}
+	return list, time.Hour
+}
+
+// exec 投递任务, 成功或放弃后删除, 失败时退避后重新调度
+func (s *Scheduler) exec(it *item) {
+	err := s.deliver(it.job)
+	var retry *Job
+	if err != nil && !IsPermanent(err) && it.job.Attempts+1 < s.maxAttempts {
+		j := it.job
+		j.Attempts++
+		j.At = time.Now().Add(s.retryDelay(j.Attempts))
+		retry = &j
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.busy--
+	s.notify()
+	// 投递期间被取消或替换
+	if s.jobs[it.job.Id] != it {
+		return
+	}
+	if retry == nil {
+		delete(s.jobs, it.job.Id)
+		// 删除失败时重启后会重复投递
+		_ = s.store.Delete(it.job.Id)
+		return
+	}
+	// 保存失败时重启后按原次数重试
+	_ = s.store.Save(*retry)
+	s.push(*retry)
+}
+
+func (s *Scheduler) retryDelay(attempts int) time.Duration {
+	d := s.backoff
+	for i := 1; i < attempts && d < s.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > s.maxBackoff {
+		d = s.maxBackoff
+	}
+	return d
+}
+
+type item struct {
+	job   Job
+	index int // 在队列中的位置, 投递中为-1
+}
+
+// queue 按投递时间的最小堆
+type queue []*item
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].job.At.Before(q[j].job.At) }
+func (q queue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *queue) Push(x interface{}) {
+	it := x.(*item)
+	it.index = len(*q)
+	*q = append(*q, it)
+}
+
+func (q *queue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	it := old[n-1]
+	it.index = -1
+	old[n-1] = nil
+	*q = old[:n-1]
+	return it
+}
@@ -0,0 +1,200 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "schedule.json"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	s := New(store)
+	if _, err = s.Add(Job{At: time.Now()}); err != ErrNotStarted {
+		t.Error("need not started error")
+		return
+	}
+	delivered := make(chan Job, 2)
+	if err = s.Start(ctx, func(j Job) error { delivered <- j; return nil }); err != nil {
+		t.Error(err)
+		return
+	}
+	later, _ := s.Add(Job{At: time.Now().Add(time.Hour), TargetId: "later"})
+	canceled, _ := s.Add(Job{At: time.Now().Add(time.Millisecond * 30), TargetId: "canceled"})
+	_, _ = s.Add(Job{At: time.Now().Add(time.Millisecond * 20), TargetId: "soon"})
+	if ok, _ := s.Cancel(canceled.Id); !ok {
+		t.Error("cancel failed")
+		return
+	}
+
+	select {
+	case j := <-delivered:
+		if j.TargetId != "soon" {
+			t.Error("need deliver soon job first", j.TargetId)
+			return
+		}
+	case <-time.After(time.Second):
+		t.Error("need deliver")
+		return
+	}
+	select {
+	case j := <-delivered:
+		t.Error("need not deliver", j.TargetId)
+		return
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	// 重新打开存储后只剩未到期的任务
+	reopened, err := NewFileStore(store.path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if list, _ := reopened.List(); len(list) != 1 || list[0].Id != later.Id {
+		t.Error("need persist pending job", list)
+		return
+	}
+	if list, total := s.List(nil, 0, 10); total != 1 || list[0].Id != later.Id {
+		t.Error("list failed", list)
+	}
+}
+
+func TestSchedulerRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMemoryStore()
+	s := New(store, Retry(3, time.Millisecond*10, time.Millisecond*20))
+	var calls int32
+	done := make(chan Job, 1)
+	if err := s.Start(ctx, func(j Job) error {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			if list, _ := store.List(); len(list) != 1 {
+				t.Error("need keep job in store until delivered")
+			}
+			return errors.New("offline")
+		}
+		done <- j
+		return nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	_, _ = s.Add(Job{At: time.Now()})
+	select {
+	case j := <-done:
+		if j.Attempts != 2 {
+			t.Error("need 2 failed attempts", j.Attempts)
+		}
+	case <-time.After(time.Second):
+		t.Error("need retry until delivered")
+		return
+	}
+	time.Sleep(time.Millisecond * 10)
+	if list, _ := store.List(); len(list) != 0 {
+		t.Error("need delete delivered job", list)
+	}
+}
+
+func TestSchedulerGiveUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMemoryStore()
+	s := New(store, Retry(2, time.Millisecond, time.Millisecond))
+	var calls int32
+	if err := s.Start(ctx, func(j Job) error {
+		atomic.AddInt32(&calls, 1)
+		if j.TargetId == "bad" {
+			return Permanent(errors.New("invalid"))
+		}
+		return errors.New("offline")
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	_, _ = s.Add(Job{At: time.Now(), TargetId: "bad"})
+	_, _ = s.Add(Job{At: time.Now(), TargetId: "offline"})
+	time.Sleep(time.Millisecond * 100)
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Error("need 1 attempt for permanent error and 2 for retryable error", n)
+	}
+	if _, total := s.List(nil, 0, 0); total != 0 {
+		t.Error("need drop jobs after giving up", total)
+	}
+	if list, _ := store.List(); len(list) != 0 {
+		t.Error("need delete dropped jobs", list)
+	}
+}
+
+func TestSchedulerWorkers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := New(nil, Workers(2))
+	var running, peak int32
+	release := make(chan struct{})
+	done := make(chan struct{}, 10)
+	if err := s.Start(ctx, func(j Job) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&running, -1)
+		done <- struct{}{}
+		return nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 10; i++ {
+		_, _ = s.Add(Job{At: time.Now()})
+	}
+	time.Sleep(time.Millisecond * 20)
+	close(release)
+	for i := 0; i < 10; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("need deliver all jobs")
+			return
+		}
+	}
+	if p := atomic.LoadInt32(&peak); p != 2 {
+		t.Error("need at most 2 concurrent deliveries", p)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.log")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_ = store.Save(Job{Id: "a", TargetId: "a"})
+	_ = store.Save(Job{Id: "b", TargetId: "b"})
+	connectedAt := time.Now()
+	_ = store.Save(Job{Id: "a", Fd: 3, ConnectedAt: connectedAt, TargetId: "a2"})
+	_ = store.Delete("b")
+	_ = store.Close()
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer reopened.Close()
+	if list, _ := reopened.List(); len(list) != 1 || list[0].TargetId != "a2" || !list[0].ConnectedAt.Equal(connectedAt) {
+		t.Error("need replay log", list)
+	}
+}
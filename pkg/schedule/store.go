@@ -0,0 +1,245 @@
+package schedule
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store 定时任务的持久化存储
+type Store interface {
+	Save(j Job) error
+	Delete(id string) error
+	List() ([]Job, error)
+}
+
+// MemoryStore 内存存储, 重启后丢失
+type MemoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]Job)}
+}
+
+func (s *MemoryStore) Save(j Job) error {
+	s.mu.Lock()
+	s.jobs[j.Id] = j
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	delete(s.jobs, id)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) List() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		list = append(list, j)
+	}
+	return list, nil
+}
+
+// FileStore 本地文件存储, 变化以追加日志的方式写入并fsync, 无效记录过多时压缩重写
+type FileStore struct {
+	mu      sync.Mutex
+	path    string
+	f       *os.File
+	jobs    map[string]Job
+	records int // 日志中的记录数
+}
+
+// fileRecord 日志记录, Job为nil时表示删除
+type fileRecord struct {
+	Id  string `json:"id"`
+	Job *Job   `json:"job,omitempty"`
+}
+
+// 日志记录数超过存活任务数的倍数时压缩
+const (
+	compactMinRecords = 1024
+	compactRatio      = 2
+)
+
+// NewFileStore 打开文件存储, 文件不存在时创建, 兼容旧的整体json数组格式
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, jobs: make(map[string]Job)}
+	b, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if b = bytes.TrimSpace(b); len(b) > 0 {
+		if b[0] == '[' {
+			var list []Job
+			if err = json.Unmarshal(b, &list); err != nil {
+				return nil, err
+			}
+			for _, j := range list {
+				s.jobs[j.Id] = j
+			}
+		} else if err = s.load(b); err != nil {
+			return nil, err
+		}
+	}
+	// 打开时压缩一次, 去掉无效记录和崩溃时写了一半的记录
+	if err = s.compact(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileStore) load(b []byte) error {
+	lines := bytes.Split(b, []byte{'\n'})
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		var r fileRecord
+		if err := json.Unmarshal(line, &r); err != nil {
+			// 最后一条可能在崩溃时未写完
+			if i == len(lines)-1 {
+				return nil
+			}
+			return err
+		}
+		if r.Job != nil {
+			s.jobs[r.Id] = *r.Job
+		} else {
+			delete(s.jobs, r.Id)
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) Save(j Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.append(fileRecord{Id: j.Id, Job: &j}); err != nil {
+		return err
+	}
+	s.jobs[j.Id] = j
+	return s.maybeCompact()
+}
+
+func (s *FileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[id]; !ok {
+		return nil
+	}
+	if err := s.append(fileRecord{Id: id}); err != nil {
+		return err
+	}
+	delete(s.jobs, id)
+	return s.maybeCompact()
+}
+
+func (s *FileStore) List() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		list = append(list, j)
+	}
+	return list, nil
+}
+
+// Close 关闭日志文件
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+func (s *FileStore) append(r fileRecord) error {
+	if s.f == nil {
+		return os.ErrClosed
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err = s.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	if err = s.f.Sync(); err != nil {
+		return err
+	}
+	s.records++
+	return nil
+}
+
+func (s *FileStore) maybeCompact() error {
+	if s.records < compactMinRecords || s.records < len(s.jobs)*compactRatio {
+		return nil
+	}
+	// 压缩失败不影响已写入的记录
+	_ = s.compact()
+	return nil
+}
+
+// compact 将存活任务写入临时文件, fsync后替换日志
+func (s *FileStore) compact() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for id := range s.jobs {
+		j := s.jobs[id]
+		b, err1 := json.Marshal(fileRecord{Id: id, Job: &j})
+		if err1 != nil {
+			_ = f.Close()
+			return err1
+		}
+		_, _ = w.Write(append(b, '\n'))
+	}
+	if err = w.Flush(); err == nil {
+		err = f.Sync()
+	}
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(s.path))
+	if s.f != nil {
+		_ = s.f.Close()
+	}
+	if s.f, err = os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0644); err != nil {
+		return err
+	}
+	s.records = len(s.jobs)
+	return nil
+}
+
+// syncDir 持久化目录项, 保证rename在断电后生效, 不支持的平台忽略
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+}
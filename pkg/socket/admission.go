@@ -0,0 +1,159 @@
+package socket
+
+import (
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/limiter"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// AdmissionConfig 连接准入控制, 各项为0时不限制
+type AdmissionConfig struct {
+	MaxConnections     int     // 最大连接数
+	MaxPerIp           int     // 单个来源ip的最大连接数
+	AcceptRate         float64 // 每秒允许的新连接数
+	AcceptBurst        int     // 新连接的突发数, 默认同AcceptRate
+	MaxUnauthenticated int     // 未完成authenticate的最大连接数
+	ProxyProtocol      bool    // 前置代理使用proxy protocol, 单ip限制在解析到真实ip后进行
+}
+
+var (
+	ErrMaxConnections     = errors.New("max connections exceeded")
+	ErrMaxPerIp           = errors.New("max connections per ip exceeded")
+	ErrAcceptRate         = errors.New("accept rate exceeded")
+	ErrMaxUnauthenticated = errors.New("max unauthenticated connections exceeded")
+	ErrRealIpSet          = errors.New("real ip already set")
+)
+
+type admission struct {
+	config        AdmissionConfig
+	rate          *limiter.RateLimiter
+	total         int64
+	authenticated int64
+	authedFds     sync.Map // fd => struct{}
+	fdIps         sync.Map // fd => ip
+	ipMu          sync.Mutex
+	ipCounts      map[string]int
+}
+
+func newAdmission(c *AdmissionConfig) *admission {
+	a := &admission{
+		ipCounts: make(map[string]int),
+	}
+	if c != nil {
+		a.config = *c
+	}
+	if a.config.AcceptRate > 0 {
+		a.rate = limiter.NewRateLimiter(a.config.AcceptRate, a.config.AcceptBurst)
+	}
+	return a
+}
+
+func (a *admission) add(c Conn) {
+	atomic.AddInt64(&a.total, 1)
+	a.countIp(c.Fd(), ConnIp(c))
+}
+
+func (a *admission) del(c Conn) {
+	atomic.AddInt64(&a.total, -1)
+	if _, ok := a.authedFds.LoadAndDelete(c.Fd()); ok {
+		atomic.AddInt64(&a.authenticated, -1)
+	}
+	if ip, ok := a.fdIps.LoadAndDelete(c.Fd()); ok {
+		a.ipMu.Lock()
+		a.decIp(ip.(string))
+		a.ipMu.Unlock()
+	}
+}
+
+func (a *admission) authenticate(fd int, authenticated bool) {
+	if authenticated {
+		if _, loaded := a.authedFds.LoadOrStore(fd, struct{}{}); !loaded {
+			atomic.AddInt64(&a.authenticated, 1)
+		}
+		return
+	}
+	if _, ok := a.authedFds.LoadAndDelete(fd); ok {
+		atomic.AddInt64(&a.authenticated, -1)
+	}
+}
+
+func (a *admission) countIp(fd int, ip string) int {
+	a.ipMu.Lock()
+	defer a.ipMu.Unlock()
+	if old, ok := a.fdIps.Load(fd); ok {
+		if old.(string) == ip {
+			return a.ipCounts[ip]
+		}
+		a.decIp(old.(string))
+	}
+	if ip == "" {
+		a.fdIps.Delete(fd)
+		return 0
+	}
+	a.fdIps.Store(fd, ip)
+	a.ipCounts[ip]++
+	return a.ipCounts[ip]
+}
+
+func (a *admission) decIp(ip string) {
+	if n := a.ipCounts[ip] - 1; n > 0 {
+		a.ipCounts[ip] = n
+	} else {
+		delete(a.ipCounts, ip)
+	}
+}
+
+func (a *admission) ipCount(ip string) int {
+	a.ipMu.Lock()
+	defer a.ipMu.Unlock()
+	return a.ipCounts[ip]
+}
+
+// admit 检查已加入的连接是否满足准入规则, 其他规则都满足后才消耗新连接速率的令牌
+func (a *admission) admit(c Conn) error {
+	total := atomic.LoadInt64(&a.total)
+	if a.config.MaxConnections > 0 && total > int64(a.config.MaxConnections) {
+		return ErrMaxConnections
+	}
+	if a.config.MaxUnauthenticated > 0 && total-atomic.LoadInt64(&a.authenticated) > int64(a.config.MaxUnauthenticated) {
+		return ErrMaxUnauthenticated
+	}
+	if a.config.MaxPerIp > 0 && !a.config.ProxyProtocol {
+		if ip, ok := a.fdIps.Load(c.Fd()); ok && a.ipCount(ip.(string)) > a.config.MaxPerIp {
+			return ErrMaxPerIp
+		}
+	}
+	if a.rate != nil && !a.rate.Allow() {
+		return ErrAcceptRate
+	}
+	return nil
+}
+
+// admitRealIp 以真实ip重新统计并检查单ip限制, 被拒绝时归还admit消耗的令牌
+func (a *admission) admitRealIp(c Conn, ip string) error {
+	n := a.countIp(c.Fd(), ip)
+	if a.config.MaxPerIp > 0 && n > a.config.MaxPerIp {
+		if a.rate != nil {
+			a.rate.Return()
+		}
+		return ErrMaxPerIp
+	}
+	return nil
+}
+
+// ConnIp 返回连接的来源ip, 优先使用代理解析出的真实ip
+func ConnIp(c Conn) string {
+	if ip := c.Context().RealIp(); ip != "" {
+		return ip
+	}
+	if c.RemoteAddr() == nil {
+		return ""
+	}
+	addr := c.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,54 @@
+package socket
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAdmissionRejectKeepsToken(t *testing.T) {
+	a := newAdmission(&AdmissionConfig{MaxConnections: 1, AcceptRate: 0.001, AcceptBurst: 1})
+	c1 := &drainConn{fd: 1, ctx: NewContext()}
+	c2 := &drainConn{fd: 2, ctx: NewContext()}
+	a.add(c1)
+	a.add(c2)
+	if err := a.admit(c2); !errors.Is(err, ErrMaxConnections) {
+		t.Errorf("need max connections, got %v", err)
+		return
+	}
+	a.del(c2)
+	if err := a.admit(c1); err != nil {
+		t.Errorf("rejected connection need not consume rate token, got %v", err)
+		return
+	}
+	c3 := &drainConn{fd: 3, ctx: NewContext()}
+	a.add(c3)
+	a.del(c1)
+	if err := a.admit(c3); !errors.Is(err, ErrAcceptRate) {
+		t.Errorf("need accept rate, got %v", err)
+	}
+}
+
+func TestAdmissionRealIpReturnsToken(t *testing.T) {
+	a := newAdmission(&AdmissionConfig{MaxPerIp: 1, ProxyProtocol: true, AcceptRate: 0.001, AcceptBurst: 2})
+	conns := []*drainConn{{fd: 1, ctx: NewContext()}, {fd: 2, ctx: NewContext()}, {fd: 3, ctx: NewContext()}}
+	for i, c := range conns[:2] {
+		a.add(c)
+		if err := a.admit(c); err != nil {
+			t.Errorf("conn %d need admitted, got %v", i, err)
+			return
+		}
+	}
+	if err := a.admitRealIp(conns[0], "10.0.0.1"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := a.admitRealIp(conns[1], "10.0.0.1"); !errors.Is(err, ErrMaxPerIp) {
+		t.Errorf("need max per ip, got %v", err)
+		return
+	}
+	a.del(conns[1])
+	a.add(conns[2])
+	if err := a.admit(conns[2]); err != nil {
+		t.Errorf("need token returned by real ip rejection, got %v", err)
+	}
+}
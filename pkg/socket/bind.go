@@ -15,23 +15,25 @@ func newIDManager() *IDManager {
 	}
 }
 
-func (m *IDManager) Add(id string, fd int) {
+// Add 绑定id和fd, id由无到有时返回true
+func (m *IDManager) Add(id string, fd int) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	list, exists := m.data[id]
 	if !exists {
 		m.data[id] = []int{fd}
-		return
+		return true
 	}
 
 	for _, existingFD := range list {
 		if existingFD == fd {
-			return
+			return false
 		}
 	}
 
 	m.data[id] = append(list, fd)
+	return false
 }
 
 func (m *IDManager) Get(id string) []int {
@@ -48,13 +50,14 @@ func (m *IDManager) Get(id string) []int {
 	return copyList
 }
 
-func (m *IDManager) Del(id string, fd int) {
+// Del 解绑id和fd, id由有到无时返回true
+func (m *IDManager) Del(id string, fd int) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	list, exists := m.data[id]
 	if !exists {
-		return
+		return false
 	}
 
 	index := -1
@@ -68,6 +71,8 @@ func (m *IDManager) Del(id string, fd int) {
 		m.data[id] = append(list[:index], list[index+1:]...)
 		if len(m.data[id]) == 0 {
 			delete(m.data, id)
+			return true
 		}
 	}
+	return false
 }
@@ -0,0 +1,15 @@
+package socket
+
+// ReasonCloser 关闭时能向客户端携带状态码和原因的连接, 如websocket的close帧
+type ReasonCloser interface {
+	CloseWithReason(code int, reason string)
+}
+
+// CloseWithReason 携带原因关闭连接, 连接不支持时直接关闭
+func CloseWithReason(c Conn, code int, reason string) {
+	if rc, ok := c.(ReasonCloser); ok {
+		rc.CloseWithReason(code, reason)
+		return
+	}
+	c.Close()
+}
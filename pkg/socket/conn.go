@@ -4,6 +4,7 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -26,6 +27,12 @@ type ConnContext struct {
 	authUser       *AuthUser
 	optional       sync.Map
 	authentication *Authentication
+	realIp         string
+	authExpireAt   int64 // unix nano
+	probeAt        int64 // unix nano, 未收到响应的心跳探测的发出时间
+	rtt            int64 // 最近一次心跳探测的往返时延
+	labelMu        sync.RWMutex
+	labels         map[string]string
 }
 
 // ConnId id绑定信息
@@ -116,6 +123,30 @@ func (c *ConnContext) LastActiveAt() time.Time {
 	return c.lastActiveAt
 }
 
+// Probed 记录发出了心跳探测
+func (c *ConnContext) Probed() {
+	atomic.StoreInt64(&c.probeAt, time.Now().UnixNano())
+}
+
+// Probing 是否有未收到响应的心跳探测
+func (c *ConnContext) Probing() bool {
+	return atomic.LoadInt64(&c.probeAt) > 0
+}
+
+// Pong 收到心跳响应(websocket的pong帧或网关层的Pong), 按未响应的探测计算往返时延
+func (c *ConnContext) Pong() {
+	now := time.Now()
+	if at := atomic.SwapInt64(&c.probeAt, 0); at > 0 {
+		atomic.StoreInt64(&c.rtt, now.UnixNano()-at)
+	}
+	c.lastActiveAt = now
+}
+
+// Rtt 最近一次心跳探测的往返时延, 未探测过时为0
+func (c *ConnContext) Rtt() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.rtt))
+}
+
 func (c *ConnContext) bind(id ConnId) {
 	if id.Type != "" && id.Id != "" {
 		c.ids[id.Type] = id
@@ -179,6 +210,37 @@ func (c *ConnContext) TypedId(typ string) ConnId {
 	return ConnId{}
 }
 
+func (c *ConnContext) setRealIp(ip string) {
+	c.realIp = ip
+}
+
+// RealIp 返回连接的真实来源ip，未经代理时为空
+func (c *ConnContext) RealIp() string {
+	return c.realIp
+}
+
+func (c *ConnContext) setAuthExpireAt(t time.Time) {
+	var v int64
+	if !t.IsZero() {
+		v = t.UnixNano()
+	}
+	atomic.StoreInt64(&c.authExpireAt, v)
+}
+
+// AuthExpireAt 返回认证的过期时间，零值为不过期
+func (c *ConnContext) AuthExpireAt() time.Time {
+	if v := atomic.LoadInt64(&c.authExpireAt); v > 0 {
+		return time.Unix(0, v)
+	}
+	return time.Time{}
+}
+
+// AuthExpired 认证是否已过期
+func (c *ConnContext) AuthExpired() bool {
+	v := atomic.LoadInt64(&c.authExpireAt)
+	return v > 0 && v <= time.Now().UnixNano()
+}
+
 func (c *ConnContext) Upgrade() {
 	c.upgraded = true
 }
@@ -229,3 +291,50 @@ func (c *ConnContext) SetOptional(key, v interface{}) {
 func (c *ConnContext) DelOptional(key interface{}) {
 	c.optional.Delete(key)
 }
+
+// SetLabel 设置自定义标签, 用于按条件筛选连接
+func (c *ConnContext) SetLabel(key, value string) {
+	c.labelMu.Lock()
+	defer c.labelMu.Unlock()
+	if c.labels == nil {
+		c.labels = make(map[string]string)
+	}
+	c.labels[key] = value
+}
+
+// DelLabel 删除自定义标签
+func (c *ConnContext) DelLabel(key string) {
+	c.labelMu.Lock()
+	defer c.labelMu.Unlock()
+	delete(c.labels, key)
+}
+
+// Label 返回自定义标签
+func (c *ConnContext) Label(key string) (v string, ok bool) {
+	c.labelMu.RLock()
+	defer c.labelMu.RUnlock()
+	v, ok = c.labels[key]
+	return
+}
+
+// Labels 返回全部自定义标签的副本
+func (c *ConnContext) Labels() map[string]string {
+	c.labelMu.RLock()
+	defer c.labelMu.RUnlock()
+	labels := make(map[string]string, len(c.labels))
+	for k, v := range c.labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+// Cid 返回连接所属的公司id, 优先authenticate的公司id, 其次认证用户的公司id
+func (c *ConnContext) Cid() uint32 {
+	if a := c.authentication; a != nil && a.Cid > 0 {
+		return a.Cid
+	}
+	if u := c.authUser; u != nil {
+		return uint32(u.Cid())
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package socket
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// 排空的默认配置
+const (
+	DefaultDrainTimeout       = time.Second * 10
+	DefaultDrainBatchSize     = 500
+	DefaultDrainBatchInterval = time.Millisecond * 200
+)
+
+var ErrDraining = errors.New("server draining")
+
+// AcceptStopper 引擎停止接受新连接, 已建立的连接不受影响, 排空开始时调用
+type AcceptStopper interface {
+	StopAccept() error
+}
+
+// DrainConfig 排空配置
+type DrainConfig struct {
+	Timeout       time.Duration // 等待处理中消息完成的最长时间
+	BatchSize     int           // 每批关闭的连接数
+	BatchInterval time.Duration // 批次间隔
+	Notify        func(c Conn)  // 排空开始时通知连接, 为nil时不通知
+	Close         func(c Conn)  // 关闭剩余的连接, 为nil时直接关闭
+}
+
+// Draining 是否正在排空
+func (s *Server) Draining() bool {
+	return atomic.LoadInt32(&s.draining) == 1
+}
+
+// Inflight 正在处理的消息数
+func (s *Server) Inflight() int64 {
+	return atomic.LoadInt64(&s.inflight)
+}
+
+// Drain 排空服务: 停止接受及拒绝新连接, 通知已有连接, 等待处理中的消息完成或超时, 再分批关闭剩余连接, 最后停止引擎
+// ctx结束时不再等待, 立即关闭剩余连接, 重复调用返回ErrDraining
+func (s *Server) Drain(ctx context.Context, c DrainConfig) error {
+	if !atomic.CompareAndSwapInt32(&s.draining, 0, 1) {
+		return ErrDraining
+	}
+	defer s.Stop()
+	// 引擎支持时在监听层停止接受, 负载均衡不再能与本节点建立连接, 否则只在建立后逐个拒绝
+	if as, ok := s.engine.(AcceptStopper); ok {
+		_ = as.StopAccept()
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultDrainTimeout
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultDrainBatchSize
+	}
+	if c.BatchInterval <= 0 {
+		c.BatchInterval = DefaultDrainBatchInterval
+	}
+	if c.Close == nil {
+		c.Close = func(cc Conn) {
+			cc.Close()
+		}
+	}
+	if c.Notify != nil {
+		s.RangeConnections(func(cc Conn) bool {
+			c.Notify(cc)
+			return true
+		})
+	}
+	err := s.waitInflight(ctx, c.Timeout)
+
+	var batch []Conn
+	s.RangeConnections(func(cc Conn) bool {
+		batch = append(batch, cc)
+		return true
+	})
+	for len(batch) > 0 {
+		n := c.BatchSize
+		if n > len(batch) || err != nil {
+			n = len(batch)
+		}
+		for _, cc := range batch[:n] {
+			c.Close(cc)
+		}
+		if batch = batch[n:]; len(batch) == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-time.After(c.BatchInterval):
+		}
+	}
+	return err
+}
+
+// waitInflight 等待处理中的消息完成, 超时不算错误
+func (s *Server) waitInflight(ctx context.Context, timeout time.Duration) error {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(time.Millisecond * 10)
+	defer ticker.Stop()
+	for s.Inflight() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return nil
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
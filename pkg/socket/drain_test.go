@@ -0,0 +1,108 @@
+package socket
+
+import (
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type drainEngine struct {
+	stopped        bool
+	acceptsStopped bool
+}
+
+func (e *drainEngine) StopAccept() error {
+	e.acceptsStopped = true
+	return nil
+}
+
+func (e *drainEngine) Run(context.Context, *Server, Event, sockettype.SocketType, int, *Config) error {
+	return nil
+}
+
+func (e *drainEngine) Stop() error {
+	e.stopped = true
+	return nil
+}
+
+type drainConn struct {
+	fd       int
+	ctx      *ConnContext
+	s        *Server
+	notified bool
+	closedAt time.Time
+}
+
+func (c *drainConn) Fd() int               { return c.fd }
+func (c *drainConn) Context() *ConnContext { return c.ctx }
+func (c *drainConn) Read() ([]byte, error) { return nil, nil }
+func (c *drainConn) Write([]byte) error    { return nil }
+func (c *drainConn) Close() {
+	if c.closedAt.IsZero() {
+		c.closedAt = time.Now()
+		c.s.event.OnClose(c.s, c, nil)
+	}
+}
+func (c *drainConn) LocalAddr() net.Addr  { return nil }
+func (c *drainConn) RemoteAddr() net.Addr { return nil }
+
+func TestDrain(t *testing.T) {
+	e := &drainEngine{}
+	s := New(context.Background(), sockettype.TCP, 0, e, &BuiltinEvent{}, nil, nil)
+	conns := make([]*drainConn, 5)
+	for i := range conns {
+		conns[i] = &drainConn{fd: i + 1, ctx: NewContext(), s: s}
+		s.event.OnOpen(s, conns[i])
+	}
+	atomic.AddInt64(&s.inflight, 1)
+	start := time.Now()
+	time.AfterFunc(30*time.Millisecond, func() {
+		atomic.AddInt64(&s.inflight, -1)
+	})
+	var admitErr error
+	err := s.Drain(context.Background(), DrainConfig{
+		Timeout:       time.Second,
+		BatchSize:     2,
+		BatchInterval: 20 * time.Millisecond,
+		Notify: func(c Conn) {
+			c.(*drainConn).notified = true
+			admitErr = s.Admit(c)
+		},
+	})
+	if err != nil || !errors.Is(admitErr, ErrDraining) {
+		t.Errorf("need reject new connections while draining, err=%v, admit=%v", err, admitErr)
+		return
+	}
+	first, last := conns[0].closedAt, conns[0].closedAt
+	for _, c := range conns {
+		if !c.notified || c.closedAt.IsZero() {
+			t.Error("need notify and close all connections")
+			return
+		}
+		if c.closedAt.Before(first) {
+			first = c.closedAt
+		}
+		if c.closedAt.After(last) {
+			last = c.closedAt
+		}
+	}
+	if first.Sub(start) < 30*time.Millisecond {
+		t.Error("need wait inflight messages before closing")
+		return
+	}
+	if last.Sub(first) < 40*time.Millisecond {
+		t.Error("need close in paced batches")
+		return
+	}
+	if !e.acceptsStopped {
+		t.Error("need stop accepting at the engine")
+		return
+	}
+	if !e.stopped || s.ConnectionNum() != 0 || s.Drain(context.Background(), DrainConfig{}) != ErrDraining {
+		t.Error("need stop engine once drained")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"net"
 	"net/http"
+	"strconv"
 )
 
 type Conn struct {
@@ -17,6 +18,7 @@ type Conn struct {
 	rq          []byte
 	resp        []byte
 	doing       bool
+	closeReason string
 }
 
 func NewConn(id string, fd int, q *http.Request, closeFn func(id string)) *Conn {
@@ -42,6 +44,11 @@ func (c *Conn) Context() *socket.ConnContext {
 	return c.connContext
 }
 
+// DirectWrite 写入的是本次请求的响应, 不能经过出站队列
+func (c *Conn) DirectWrite() bool {
+	return true
+}
+
 func (c *Conn) Read() ([]byte, error) {
 	return c.rq, nil
 }
@@ -58,6 +65,12 @@ func (c *Conn) Close() {
 	c.closeFn(c.id)
 }
 
+// CloseWithReason 关闭原因通过X-Disconnect响应头告知客户端
+func (c *Conn) CloseWithReason(code int, reason string) {
+	c.closeReason = strconv.Itoa(code) + " " + reason
+	c.Close()
+}
+
 func (c *Conn) LocalAddr() net.Addr {
 	return c.local
 }
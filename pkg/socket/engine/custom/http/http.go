@@ -27,6 +27,7 @@ type Engine struct {
 	config       *socket.Config
 	index        int64
 	connections  sync.Map // target ==> conn
+	disconnects  sync.Map // target ==> disconnect, 在该target的下一次响应中告知, 超过disconnectTtl未再请求的被清理
 	e            *http.Http
 	ip           string
 	limiter      *limiter.TimeLimiter
@@ -42,6 +43,42 @@ func New(ip string) *Engine {
 	}
 }
 
+// disconnectTtl 关闭原因的保留时间
+const disconnectTtl = time.Minute * 5
+
+type disconnect struct {
+	reason string
+	at     time.Time
+}
+
+// disconnectHeader 告知客户端上次连接被网关关闭的原因, 读取后即删除
+func (e *Engine) disconnectHeader(c *gin.Context, target string) {
+	if v, ok := e.disconnects.LoadAndDelete(target); ok {
+		if d := v.(disconnect); time.Since(d.at) < disconnectTtl {
+			c.Writer.Header().Set("X-Disconnect", d.reason)
+		}
+	}
+}
+
+// sweepDisconnects 定时清理未再请求的target的关闭原因
+func (e *Engine) sweepDisconnects() {
+	ticker := time.NewTicker(disconnectTtl)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case now := <-ticker.C:
+			e.disconnects.Range(func(key, value interface{}) bool {
+				if now.Sub(value.(disconnect).at) >= disconnectTtl {
+					e.disconnects.Delete(key)
+				}
+				return true
+			})
+		}
+	}
+}
+
 func (e *Engine) genFd() int64 {
 	atomic.AddInt64(&e.index, 1)
 	return atomic.LoadInt64(&e.index)
@@ -88,12 +125,16 @@ func (e *Engine) newHttp() (err error) {
 		if !e.limiter.Access(target) {
 			return
 		}
+		e.disconnectHeader(c, target)
 		var conn *Conn
 		if v, ok := e.connections.Load(target); !ok {
 			conn = NewConn(target, int(e.genFd()), c.Request, func(id string) {
 				if v1, ok1 := e.connections.Load(target); ok1 {
 					v2 := v1.(*Conn)
 					e.connections.Delete(id)
+					if v2.closeReason != "" {
+						e.disconnects.Store(id, disconnect{reason: v2.closeReason, at: time.Now()})
+					}
 					e.eventHandler.OnClose(e.s, v2, nil)
 				}
 			})
@@ -125,7 +166,13 @@ func (e *Engine) newHttp() (err error) {
 			c.Writer.Header().Set("X-Error", string(respData))
 		}
 
-		rqData, _ := io.ReadAll(c.Request.Body)
+		rqData, err := readBody(c.Request.Body, e.s.SizeLimit())
+		if err != nil {
+			c.Writer.Header().Set("X-Error", err.Error())
+			c.Status(http2.StatusRequestEntityTooLarge)
+			e.limiter.Hit(target)
+			return
+		}
 		var respData []byte
 		if len(rqData) > 0 {
 			conn.SetRq(rqData)
@@ -133,6 +180,7 @@ func (e *Engine) newHttp() (err error) {
 			time.Sleep(time.Millisecond * 100)
 			respData = conn.GetResp()
 		}
+		e.disconnectHeader(c, target)
 		c.String(http2.StatusOK, string(respData))
 	})
 	e.e = s
@@ -140,6 +188,18 @@ func (e *Engine) newHttp() (err error) {
 	return nil
 }
 
+// readBody 读取请求体, 超过limit.MaxFrame时返回socket.ErrFrameTooLarge, 不读取剩余部分
+func readBody(r io.Reader, limit socket.SizeLimit) ([]byte, error) {
+	if limit.MaxFrame > 0 {
+		r = io.LimitReader(r, int64(limit.MaxFrame)+1)
+	}
+	b, _ := io.ReadAll(r)
+	if err := limit.CheckFrame(len(b)); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 func (e *Engine) Stop() error {
 	e.e.Close()
 	e.eventHandler.OnShutdown(e.s)
@@ -161,6 +221,7 @@ func (e *Engine) Run(ctx context.Context, s *socket.Server, eventHandler socket.
 	}
 	e.eventHandler.OnBoot(e.s)
 	e.tick()
+	go e.sweepDisconnects()
 	return e.e.RunAndServ()
 }
 
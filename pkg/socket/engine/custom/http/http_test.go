@@ -0,0 +1,31 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"testing"
+)
+
+func TestReadBody(t *testing.T) {
+	cases := []struct {
+		name  string
+		size  int
+		limit socket.SizeLimit
+		want  error
+	}{
+		{"within limit", 16, socket.SizeLimit{MaxFrame: 16}, nil},
+		{"too large", 17, socket.SizeLimit{MaxFrame: 16}, socket.ErrFrameTooLarge},
+		{"unlimited", 1 << 20, socket.SizeLimit{}, nil},
+	}
+	for _, c := range cases {
+		b, err := readBody(bytes.NewReader(make([]byte, c.size)), c.limit)
+		if !errors.Is(err, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.want)
+			continue
+		}
+		if err == nil && len(b) != c.size {
+			t.Errorf("%s: read %d bytes, want %d", c.name, len(b), c.size)
+		}
+	}
+}
@@ -14,6 +14,7 @@ type Conn struct {
 	closeFn     func(id string)
 	rq          [][]byte
 	wfn         func(*Conn, []byte) error
+	rfn         func(*Conn, int, string)
 }
 
 func NewConn(id string, fd int, closeFn func(id string), wfn func(*Conn, []byte) error) *Conn {
@@ -57,6 +58,14 @@ func (c *Conn) Close() {
 	c.closeFn(c.id)
 }
 
+// CloseWithReason 告知原因后关闭
+func (c *Conn) CloseWithReason(code int, reason string) {
+	if c.rfn != nil {
+		c.rfn(c, code, reason)
+	}
+	c.Close()
+}
+
 func (c *Conn) LocalAddr() net.Addr {
 	return c.local
 }
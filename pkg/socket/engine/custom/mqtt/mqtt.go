@@ -23,6 +23,7 @@ import (
 const topicIdKey = "device_sn"
 const topicActionKey = "action"           // no action is raw pkg
 const authenticateAction = "authenticate" // authenticate action
+const disconnectAction = "disconnect"     // 网关关闭连接时告知原因, payload为 状态码:原因
 
 type Engine struct {
 	ctx          context.Context
@@ -101,6 +102,11 @@ func (e *Engine) Run(ctx context.Context, s *socket.Server, eventHandler socket.
 			if !e.limiter.Access(sn) {
 				return
 			}
+			// broker已完整接收, 只能丢弃超长的消息
+			if e.s.SizeLimit().CheckFrame(len(message.Payload())) != nil {
+				e.limiter.Hit(sn)
+				return
+			}
 			var conn *Conn
 			var raw bool
 			if v, ok := e.connections.Load(sn); !ok {
@@ -137,6 +143,13 @@ func (e *Engine) Run(ctx context.Context, s *socket.Server, eventHandler socket.
 					}
 					return e.response(cc.id, strconv.Itoa(int(pkg.Action)), pkg.Data)
 				})
+				// mqtt 3.1.1没有服务端携带原因的断开, 网关也只是broker的客户端, 无法断开设备的会话
+				// 非原始协议的连接在disconnect的action主题上告知原因, 原始协议的设备无法得知
+				conn.rfn = func(cc *Conn, code int, reason string) {
+					if !e.isRawConn(cc) && e.serverTopic != nil {
+						_ = e.response(cc.id, disconnectAction, []byte(strconv.Itoa(code)+":"+reason))
+					}
+				}
 				raw = e.isRawMessage(message)
 				conn.Context().SetOptional("mqtt_raw", raw)
 				conn.Context().SetOptional("fd-target", sn)
@@ -2,6 +2,7 @@ package gnet
 
 import (
 	"errors"
+	"github.com/gobwas/ws"
 	"github.com/gobwas/ws/wsutil"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"github.com/panjf2000/gnet/v2"
@@ -109,6 +110,22 @@ func (c *Conn) Write(b []byte) error {
 	return err
 }
 
+// Ping websocket连接发送ping帧
+func (c *Conn) Ping(payload []byte) error {
+	if !c.connContext.Upgraded() {
+		return socket.ErrPingUnsupported
+	}
+	return wsutil.WriteServerMessage(c.raw, ws.OpPing, payload)
+}
+
+// CloseWithReason websocket连接先发送携带状态码和原因的close帧
+func (c *Conn) CloseWithReason(code int, reason string) {
+	if c.connContext.Upgraded() {
+		_ = wsutil.WriteServerMessage(c.raw, ws.OpClose, ws.NewCloseFrameBody(ws.StatusCode(code), reason))
+	}
+	c.Close()
+}
+
 func (c *Conn) Close() {
 	if c.raw != nil {
 		_ = c.raw.Close()
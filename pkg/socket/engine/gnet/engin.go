@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -22,6 +23,7 @@ type Engine struct {
 	server      *socket.Server
 	connections sync.Map // fd=>conn
 	ws          bool
+	stopAccept  int32
 }
 
 func New() *Engine {
@@ -37,10 +39,19 @@ func (e *Engine) OnShutdown(gnet.Engine) {
 	e.event.OnShutdown(e.server)
 }
 
+// StopAccept 停止接受新连接, gnet无法单独关闭监听, 新连接在引擎层直接关闭, 不再交给事件处理
+func (e *Engine) StopAccept() error {
+	atomic.StoreInt32(&e.stopAccept, 1)
+	return nil
+}
+
 func (e *Engine) OnOpen(c gnet.Conn) (out []byte, action gnet.Action) {
+	if atomic.LoadInt32(&e.stopAccept) == 1 && !e.server.Type().IsUdp() {
+		return nil, gnet.Close
+	}
 	connCtx := socket.NewContext()
 	if e.ws {
-		connCtx.SetOptional("wsCodec", new(wsCodec))
+		connCtx.SetOptional("wsCodec", newWsCodec(e.server.SizeLimit()))
 	}
 	c.SetContext(connCtx)
 	var c1 *Conn
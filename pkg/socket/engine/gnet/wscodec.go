@@ -6,14 +6,24 @@ import (
 	"fmt"
 	"github.com/gobwas/ws"
 	"github.com/gobwas/ws/wsutil"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"github.com/panjf2000/gnet/v2"
 	"github.com/panjf2000/gnet/v2/pkg/logging"
 	"io"
 )
 
 type wsCodec struct {
-	buf      bytes.Buffer // 从实际socket中读取到的数据缓存
-	wsMsgBuf wsMessageBuf // ws 消息缓存
+	buf       bytes.Buffer // 从实际socket中读取到的数据缓存
+	wsMsgBuf  wsMessageBuf // ws 消息缓存
+	maxFrame  int          // 单帧最大长度, 0不限制
+	maxBuffer int          // 缓存最大长度, 0不限制
+}
+
+func newWsCodec(limit socket.SizeLimit) *wsCodec {
+	return &wsCodec{
+		maxFrame:  limit.MaxFrame,
+		maxBuffer: limit.MaxBuffer,
+	}
 }
 
 type wsMessageBuf struct {
@@ -45,6 +55,9 @@ func (w *wsCodec) upgrade(c gnet.Conn) error {
 
 func (w *wsCodec) readBufferBytes(c gnet.Conn) error {
 	size := c.InboundBuffered()
+	if w.maxBuffer > 0 && w.buf.Len()+size > w.maxBuffer {
+		return socket.ErrBufferTooLarge
+	}
 	buf := make([]byte, size)
 	read, err := c.Read(buf)
 	if err != nil {
@@ -86,6 +99,12 @@ func (w *wsCodec) readWsMessages() (messages []wsutil.Message, err error) {
 				in.Next(skipN)
 			}
 
+			if w.maxFrame > 0 && head.Length > int64(w.maxFrame) {
+				return nil, socket.ErrFrameTooLarge
+			}
+			if w.maxBuffer > 0 && int64(msgBuf.cachedBuf.Len())+head.Length > int64(w.maxBuffer) {
+				return nil, socket.ErrBufferTooLarge
+			}
 			msgBuf.curHeader = &head
 			err = ws.WriteHeader(&msgBuf.cachedBuf, head)
 			if err != nil {
@@ -127,6 +146,12 @@ func (w *wsCodec) Decode(c gnet.Conn) (outs []wsutil.Message, err error) {
 		return
 	}
 	for _, message := range messages {
+		if message.OpCode == ws.OpPong {
+			if ctx, ok := c.Context().(*socket.ConnContext); ok {
+				ctx.Pong()
+			}
+			continue
+		}
 		if message.OpCode.IsControl() {
 			err = wsutil.HandleClientControlMessage(c, message)
 			if err != nil {
@@ -0,0 +1,48 @@
+package gnet
+
+import (
+	"errors"
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"testing"
+)
+
+func TestWsCodecFrameTooLarge(t *testing.T) {
+	w := newWsCodec(socket.SizeLimit{MaxFrame: 16})
+	// 只写入头部即可拒绝, 不必等待包体
+	if err := ws.WriteHeader(&w.buf, ws.Header{Fin: true, OpCode: ws.OpBinary, Masked: true, Length: 1 << 30}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.readWsMessages(); !errors.Is(err, socket.ErrFrameTooLarge) {
+		t.Fatalf("expected ErrFrameTooLarge, got %v", err)
+	}
+}
+
+func TestWsCodecBufferTooLarge(t *testing.T) {
+	w := newWsCodec(socket.SizeLimit{MaxBuffer: 16})
+	if err := ws.WriteHeader(&w.buf, ws.Header{OpCode: ws.OpBinary, Masked: true, Length: 10}); err != nil {
+		t.Fatal(err)
+	}
+	w.buf.Write(make([]byte, 10))
+	if err := ws.WriteHeader(&w.buf, ws.Header{Fin: true, OpCode: ws.OpContinuation, Masked: true, Length: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.readWsMessages(); !errors.Is(err, socket.ErrBufferTooLarge) {
+		t.Fatalf("expected ErrBufferTooLarge, got %v", err)
+	}
+}
+
+func TestWsCodecWithinLimit(t *testing.T) {
+	w := newWsCodec(socket.SizeLimit{MaxFrame: 16, MaxBuffer: 64})
+	if err := wsutil.WriteClientMessage(&w.buf, ws.OpBinary, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	messages, err := w.readWsMessages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 || string(messages[0].Payload) != "hello" {
+		t.Fatalf("unexpected messages: %v", messages)
+	}
+}
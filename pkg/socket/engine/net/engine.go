@@ -27,6 +27,7 @@ type Engine struct {
 	udpBroadcastAddr   string
 	udpIdProvider      func([]byte) string
 	udpBodyMax         int
+	handler            engineHandler
 }
 
 func New() *Engine {
@@ -70,6 +71,7 @@ func (e *Engine) Run(ctx context.Context, s *socket.Server, ee socket.Event, t s
 	if err = handler.Init(); err != nil {
 		return err
 	}
+	e.handler = handler
 	e.event.OnBoot(e.server)
 
 	if c.Ticker {
@@ -97,6 +99,14 @@ func (e *Engine) Stop() error {
 	return nil
 }
 
+// StopAccept 停止接受新连接, udp无连接不处理
+func (e *Engine) StopAccept() error {
+	if as, ok := e.handler.(socket.AcceptStopper); ok {
+		return as.StopAccept()
+	}
+	return nil
+}
+
 func (e *Engine) UdpBroadcastListen() *Engine {
 	e.udpBroadcastListen = true
 	return e
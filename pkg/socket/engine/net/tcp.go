@@ -45,3 +45,7 @@ func (h *tcpEngineHandler) Init() error {
 func (h *tcpEngineHandler) Run(ctx context.Context) {
 	h.s.Run(ctx)
 }
+
+func (h *tcpEngineHandler) StopAccept() error {
+	return h.s.StopAccept()
+}
@@ -17,6 +17,7 @@ type Server struct {
 	onConnect    func(conn socket.Conn)
 	onDisconnect func(conn socket.Conn, err error)
 	onMessage    func(conn socket.Conn)
+	stopAccept   int32
 }
 
 func New(address string, o ...Option) *Server {
@@ -52,6 +53,14 @@ func (s *Server) Init() (err error) {
 	return err
 }
 
+// StopAccept 关闭监听, 不再接受新连接, 已建立的连接不受影响
+func (s *Server) StopAccept() error {
+	if !atomic.CompareAndSwapInt32(&s.stopAccept, 0, 1) {
+		return nil
+	}
+	return s.l.Close()
+}
+
 func (s *Server) Run(ctx context.Context) {
 	for {
 		select {
@@ -59,12 +68,19 @@ func (s *Server) Run(ctx context.Context) {
 			break
 		default:
 			conn, err := s.l.Accept()
+			if err != nil && atomic.LoadInt32(&s.stopAccept) == 1 {
+				// 监听已关闭, 等待已建立的连接处理完
+				<-ctx.Done()
+				return
+			}
 			if err == nil {
 				fd := s.fdProvider()
 				c := newConn(int(fd), conn, socket.NewContext())
 				s.onConnect(c)
 				if !c.closed {
 					go s.handConn(c)
+				} else {
+					s.onDisconnect(c, errors.New("close by server"))
 				}
 			}
 		}
@@ -15,6 +15,7 @@ type wssEngineHandler struct {
 
 func newWssEngineHandler(e *Engine, port int) *wssEngineHandler {
 	ws := websocket2.New(port,
+		websocket2.ReadLimit(int64(e.server.SizeLimit().MaxFrame)),
 		websocket2.FdProvider(func() int64 {
 			atomic.AddInt64(&e.index, 1)
 			return atomic.LoadInt64(&e.index)
@@ -24,7 +25,10 @@ func newWssEngineHandler(e *Engine, port int) *wssEngineHandler {
 			e.event.OnOpen(e.server, c)
 		}),
 		websocket2.Disconnect(func(c socket.Conn, err error) {
-			e.event.OnClose(e.server, c, errors.New("close by peer"))
+			if !socket.IsSizeLimitErr(err) {
+				err = errors.New("close by peer")
+			}
+			e.event.OnClose(e.server, c, err)
 			e.connections.Delete(c.Fd())
 		}),
 		websocket2.Message(func(c socket.Conn) {
@@ -44,3 +48,7 @@ func (h *wssEngineHandler) Init() error {
 func (h *wssEngineHandler) Run(ctx context.Context) {
 	h.s.Run(ctx)
 }
+
+func (h *wssEngineHandler) StopAccept() error {
+	return h.s.StopAccept()
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"net"
 	"sync"
+	"time"
 )
 
 type Conn struct {
@@ -49,6 +50,21 @@ func (c *Conn) Write(b []byte) error {
 	return c.raw.WriteMessage(websocket.TextMessage, b)
 }
 
+// Ping 发送ping帧
+func (c *Conn) Ping(payload []byte) error {
+	c.l.Lock()
+	defer c.l.Unlock()
+	return c.raw.WriteControl(websocket.PingMessage, payload, time.Now().Add(time.Second*10))
+}
+
+// CloseWithReason 先发送携带状态码和原因的close帧
+func (c *Conn) CloseWithReason(code int, reason string) {
+	c.l.Lock()
+	_ = c.raw.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second*10))
+	c.l.Unlock()
+	c.Close()
+}
+
 func (c *Conn) Close() {
 	if c.raw != nil {
 		_ = c.raw.Close()
@@ -35,3 +35,10 @@ func Message(f func(conn socket.Conn)) Option {
 		}
 	}
 }
+
+// ReadLimit 单个消息的最大字节数, 超过则关闭连接
+func ReadLimit(limit int64) Option {
+	return func(s *Server) {
+		s.readLimit = limit
+	}
+}
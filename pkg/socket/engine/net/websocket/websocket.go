@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"log"
+	"net"
 	"net/http"
 	"strconv"
 	"sync/atomic"
@@ -20,6 +21,9 @@ type Server struct {
 	onConnect    func(conn socket.Conn)
 	onDisconnect func(conn socket.Conn, err error)
 	onMessage    func(conn socket.Conn)
+	readLimit    int64
+	l            net.Listener
+	stopAccept   int32
 }
 
 func New(port int, o ...Option) *Server {
@@ -62,25 +66,44 @@ func (s *Server) With(o ...Option) {
 }
 
 func (s *Server) Init() error {
-	http.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
-		conn, err := s.upgrader.Upgrade(writer, request, nil)
-		if err != nil {
-			_, _ = writer.Write([]byte(err.Error()))
-			return
-		}
-		defer func() {
-			_ = conn.Close()
-		}()
+	http.HandleFunc("/", s.serve)
 
-		fd := s.fdProvider()
-		c := newWssConn(int(fd), conn, socket.NewContext())
-		s.onConnect(c)
-		if !c.closed {
-			s.handConn(c)
-		}
+	return nil
+}
+
+func (s *Server) serve(writer http.ResponseWriter, request *http.Request) {
+	conn, err := s.upgrader.Upgrade(writer, request, nil)
+	if err != nil {
+		_, _ = writer.Write([]byte(err.Error()))
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if s.readLimit > 0 {
+		conn.SetReadLimit(s.readLimit)
+	}
+	fd := s.fdProvider()
+	c := newWssConn(int(fd), conn, socket.NewContext())
+	conn.SetPongHandler(func(string) error {
+		c.Context().Pong()
+		return nil
 	})
+	s.onConnect(c)
+	if !c.closed {
+		s.handConn(c)
+	} else {
+		s.onDisconnect(c, errors.New("close by server"))
+	}
+}
 
-	return nil
+// StopAccept 关闭监听, 不再接受新连接, 已升级的连接不受影响
+func (s *Server) StopAccept() error {
+	if !atomic.CompareAndSwapInt32(&s.stopAccept, 0, 1) || s.l == nil {
+		return nil
+	}
+	return s.l.Close()
 }
 
 func (s *Server) Run(ctx context.Context) {
@@ -98,17 +121,31 @@ func (s *Server) Run(ctx context.Context) {
 			}
 		}
 	}()
-	log.Fatal(server.ListenAndServe())
+	var err error
+	if s.l, err = net.Listen("tcp", server.Addr); err != nil {
+		log.Fatal(err)
+	}
+	err = server.Serve(s.l)
+	if atomic.LoadInt32(&s.stopAccept) == 1 {
+		// 监听已关闭, 等待已建立的连接处理完
+		<-ctx.Done()
+		return
+	}
+	log.Fatal(err)
 }
 
 func (s *Server) handConn(c *Conn) {
+	closeErr := errors.New("close by peer")
 	defer func() {
 		c.Close()
-		s.onDisconnect(c, errors.New("close by peer"))
+		s.onDisconnect(c, closeErr)
 	}()
 	for {
 		_, b, err := c.raw.ReadMessage()
 		if err != nil {
+			if errors.Is(err, websocket.ErrReadLimit) {
+				closeErr = socket.ErrFrameTooLarge
+			}
 			break
 		}
 		if len(b) > 0 {
@@ -0,0 +1,62 @@
+package websocket
+
+import (
+	"errors"
+	"github.com/gorilla/websocket"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadLimit(t *testing.T) {
+	closed := make(chan error, 1)
+	messages := make(chan []byte, 1)
+	s := New(0,
+		ReadLimit(16),
+		Connect(func(socket.Conn) {}),
+		Message(func(conn socket.Conn) {
+			b, _ := conn.Read()
+			messages <- b
+		}),
+		Disconnect(func(_ socket.Conn, err error) {
+			closed <- err
+		}),
+	)
+	srv := httptest.NewServer(http.HandlerFunc(s.serve))
+	defer srv.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if err = client.WriteMessage(websocket.BinaryMessage, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case b := <-messages:
+		if string(b) != "hello" {
+			t.Fatalf("unexpected message %q", b)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message within limit not delivered")
+	}
+
+	if err = client.WriteMessage(websocket.BinaryMessage, make([]byte, 17)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err = <-closed:
+		if !errors.Is(err, socket.ErrFrameTooLarge) {
+			t.Fatalf("expected ErrFrameTooLarge, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("oversize frame did not close the connection")
+	}
+}
@@ -1,6 +1,7 @@
 package socket
 
 import (
+	"sync/atomic"
 	"time"
 )
 
@@ -57,17 +58,19 @@ func (s *countedEvent) OnShutdown(ss *Server) {
 }
 
 func (s *countedEvent) OnOpen(ss *Server, c Conn) {
-	s.s.addConn(c)
-	s.e.OnOpen(ss, c)
+	s.e.OnOpen(ss, s.s.addConn(c))
 }
 
 func (s *countedEvent) OnClose(ss *Server, c Conn, e error) {
+	c = s.s.conn(c)
 	s.s.delConn(c)
 	s.e.OnClose(ss, c, e)
 }
 
 func (s *countedEvent) OnTraffic(ss *Server, c Conn) {
-	s.e.OnTraffic(ss, c)
+	atomic.AddInt64(&s.s.inflight, 1)
+	defer atomic.AddInt64(&s.s.inflight, -1)
+	s.e.OnTraffic(ss, s.s.conn(c))
 }
 
 func (s *countedEvent) OnTick(ss *Server) (delay time.Duration) {
@@ -0,0 +1,81 @@
+package socket
+
+// ConnFilter 连接筛选条件, 各条件之间为且, 同一条件的多个值之间为或, 空条件不限制
+type ConnFilter struct {
+	Types  []string          // Authentication.Type
+	Cids   []uint32          // 公司id, 见ConnContext.Cid
+	Attrs  map[string]string // 认证用户的属性, 须全部相等
+	Ids    []ConnId          // 绑定的id, Id为空时只要求绑定了该类型
+	Labels map[string]string // 自定义标签, 须全部相等, 值为空时只要求存在该标签
+}
+
+// Empty 没有任何条件
+func (f ConnFilter) Empty() bool {
+	return len(f.Types) == 0 && len(f.Cids) == 0 && len(f.Attrs) == 0 && len(f.Ids) == 0 && len(f.Labels) == 0
+}
+
+// Match 连接是否满足条件
+func (f ConnFilter) Match(c Conn) bool {
+	if c == nil {
+		return false
+	}
+	ctx := c.Context()
+	if len(f.Types) > 0 {
+		a := ctx.Authentication()
+		if a == nil || !contains(f.Types, a.Type) {
+			return false
+		}
+	}
+	if len(f.Cids) > 0 && !contains(f.Cids, ctx.Cid()) {
+		return false
+	}
+	if len(f.Attrs) > 0 {
+		u := ctx.User()
+		if u == nil {
+			return false
+		}
+		for k, v := range f.Attrs {
+			if vv, ok := u.Attr[k]; !ok || vv != v {
+				return false
+			}
+		}
+	}
+	if len(f.Ids) > 0 {
+		matched := false
+		for _, id := range f.Ids {
+			if bound := ctx.TypedId(id.Type); bound.Id != "" && (id.Id == "" || id.Id == bound.Id) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for k, v := range f.Labels {
+		if vv, ok := ctx.Label(k); !ok || (v != "" && vv != v) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterConnections 返回满足条件的连接
+func (s *Server) FilterConnections(f ConnFilter) (list []Conn) {
+	s.RangeConnections(func(c Conn) bool {
+		if f.Match(c) {
+			list = append(list, c)
+		}
+		return true
+	})
+	return
+}
+
+func contains[T comparable](list []T, v T) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package socket
+
+import (
+	"net"
+	"testing"
+)
+
+type filterConn struct {
+	ctx *ConnContext
+}
+
+func (c *filterConn) Fd() int               { return 1 }
+func (c *filterConn) Context() *ConnContext { return c.ctx }
+func (c *filterConn) Read() ([]byte, error) { return nil, nil }
+func (c *filterConn) Write([]byte) error    { return nil }
+func (c *filterConn) Close()                {}
+func (c *filterConn) LocalAddr() net.Addr   { return nil }
+func (c *filterConn) RemoteAddr() net.Addr  { return nil }
+
+func TestConnFilter(t *testing.T) {
+	c := &filterConn{ctx: NewContext()}
+	c.ctx.authenticate(&Authentication{Type: "meter", Id: "m1", Cid: 42})
+	c.ctx.auth(&AuthUser{Id: 1, Attr: map[string]string{"role": "admin"}})
+	c.ctx.bind(ConnId{Type: "SN", Id: "m1"})
+	c.ctx.SetLabel("zone", "east")
+
+	if !(ConnFilter{Types: []string{"meter"}, Cids: []uint32{42}}).Match(c) {
+		t.Error("need match type and cid")
+		return
+	}
+	if (ConnFilter{Types: []string{"meter"}, Cids: []uint32{41}}).Match(c) {
+		t.Error("need not match other cid")
+		return
+	}
+	if !(ConnFilter{Attrs: map[string]string{"role": "admin"}, Ids: []ConnId{{Type: "SN"}}}).Match(c) {
+		t.Error("need match attrs and bind type")
+		return
+	}
+	if (ConnFilter{Ids: []ConnId{{Type: "SN", Id: "m2"}}}).Match(c) {
+		t.Error("need not match other bind id")
+		return
+	}
+	if !(ConnFilter{Labels: map[string]string{"zone": ""}}).Match(c) {
+		t.Error("need match label exists")
+		return
+	}
+	c.ctx.DelLabel("zone")
+	if (ConnFilter{Labels: map[string]string{"zone": "east"}}).Match(c) {
+		t.Error("need not match deleted label")
+	}
+}
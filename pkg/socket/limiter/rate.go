@@ -0,0 +1,65 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter 令牌桶限速器
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // 每秒产生的令牌数
+	burst  float64 // 桶容量
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter rate为每秒允许的次数，burst为允许的突发数，burst<=0时取rate
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	b := float64(burst)
+	if b <= 0 {
+		b = rate
+	}
+	if b < 1 {
+		b = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  b,
+		tokens: b,
+		last:   time.Now(),
+	}
+}
+
+// Allow 消耗一个令牌，没有可用令牌时返回false
+func (rl *RateLimiter) Allow() bool {
+	if rl.rate <= 0 {
+		return true
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	rl.tokens += now.Sub(rl.last).Seconds() * rl.rate
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+	rl.last = now
+	if rl.tokens < 1 {
+		return false
+	}
+	rl.tokens--
+	return true
+}
+
+// Return 归还一个Allow消耗的令牌, 用于放行后又被拒绝的请求
+func (rl *RateLimiter) Return() {
+	if rl.rate <= 0 {
+		return
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if rl.tokens++; rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+}
@@ -0,0 +1,51 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rl := NewRateLimiter(10, 2)
+
+	if !rl.Allow() || !rl.Allow() {
+		t.Error("need access but not")
+		return
+	}
+	if rl.Allow() {
+		t.Error("need not access but can")
+		return
+	}
+	time.Sleep(time.Millisecond * 150)
+
+	if !rl.Allow() {
+		t.Error("need access but not")
+		return
+	}
+
+	unlimited := NewRateLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		if !unlimited.Allow() {
+			t.Error("unlimited need access but not")
+			return
+		}
+	}
+}
+
+func TestRateLimiterReturn(t *testing.T) {
+	rl := NewRateLimiter(0.001, 1)
+	if !rl.Allow() || rl.Allow() {
+		t.Error("need only one token")
+		return
+	}
+	rl.Return()
+	if !rl.Allow() {
+		t.Error("need returned token available")
+		return
+	}
+	rl.Return()
+	rl.Return()
+	if !rl.Allow() || rl.Allow() {
+		t.Error("need returned tokens capped by burst")
+	}
+}
@@ -0,0 +1,261 @@
+package socket
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Priority 出站消息优先级, 控制消息先于业务消息写出, 且不会被慢消费者策略丢弃
+type Priority int
+
+const (
+	PriorityBusiness Priority = iota
+	PriorityControl
+)
+
+// SlowPolicy 慢消费者(排队字节数超过高水位)的处理方式
+type SlowPolicy int
+
+const (
+	DropOldest SlowPolicy = iota // 丢弃最早排队的业务消息
+	DropNewest                   // 丢弃新的业务消息, 直到回落到低水位
+	CloseSlow                    // 关闭连接
+)
+
+// WriteQueueConfig 连接出站队列, 写入先入队再由独立协程按优先级写出, 慢连接不会阻塞调用方
+type WriteQueueConfig struct {
+	HighWatermark int           // 排队字节数的高水位, 超过时执行Policy
+	LowWatermark  int           // 低水位, 回落到此值后恢复正常, 0为高水位的一半
+	Policy        SlowPolicy    // 慢消费者的处理方式
+	FlushTimeout  time.Duration // 关闭连接时等待队列写出的最长时间, 0为1秒
+}
+
+var (
+	ErrSlowConsumer = errors.New("slow consumer, outbound queue over high watermark")
+	ErrConnClosed   = errors.New("connection closed")
+)
+
+// DirectWriter 写入即为请求的响应(如http)的连接, 不经过出站队列
+type DirectWriter interface {
+	DirectWrite() bool
+}
+
+// PriorityWriter 支持按优先级写入的连接
+type PriorityWriter interface {
+	WritePriority(b []byte, p Priority) error
+}
+
+// WriteWithPriority 按优先级写入, 连接没有出站队列时直接写入
+func WriteWithPriority(c Conn, b []byte, p Priority) error {
+	if w, ok := c.(PriorityWriter); ok {
+		return w.WritePriority(b, p)
+	}
+	return c.Write(b)
+}
+
+// queuedConn 在引擎连接的写入前加上出站队列
+type queuedConn struct {
+	Conn
+	q *outQueue
+}
+
+func (c *queuedConn) Write(b []byte) error {
+	return c.q.push(b, PriorityBusiness)
+}
+
+func (c *queuedConn) WritePriority(b []byte, p Priority) error {
+	return c.q.push(b, p)
+}
+
+// Close 等待已排队的消息写出后关闭, 最长等待FlushTimeout
+func (c *queuedConn) Close() {
+	c.q.close(c.Conn.Close)
+}
+
+// CloseWithReason 等待已排队的消息写出后携带原因关闭
+func (c *queuedConn) CloseWithReason(code int, reason string) {
+	c.q.close(func() {
+		CloseWithReason(c.Conn, code, reason)
+	})
+}
+
+type outQueue struct {
+	raw       Conn
+	config    WriteQueueConfig
+	dropped   *uint64
+	mu        sync.Mutex
+	control   [][]byte
+	business  [][]byte
+	bytes     int
+	slow      bool
+	writing   bool
+	closing   bool
+	closed    bool
+	err       error
+	closeFn   func()
+	closeOnce sync.Once
+}
+
+func newQueuedConn(c Conn, config WriteQueueConfig, dropped *uint64) *queuedConn {
+	if config.LowWatermark <= 0 || config.LowWatermark > config.HighWatermark {
+		config.LowWatermark = config.HighWatermark / 2
+	}
+	if config.FlushTimeout <= 0 {
+		config.FlushTimeout = time.Second
+	}
+	return &queuedConn{
+		Conn: c,
+		q: &outQueue{
+			raw:     c,
+			config:  config,
+			dropped: dropped,
+		},
+	}
+}
+
+func (q *outQueue) push(b []byte, p Priority) error {
+	q.mu.Lock()
+	if q.closed || q.closing {
+		err := q.err
+		q.mu.Unlock()
+		if err == nil {
+			err = ErrConnClosed
+		}
+		return err
+	}
+	if p == PriorityBusiness && (q.slow || q.bytes+len(b) > q.config.HighWatermark) {
+		switch q.config.Policy {
+		case CloseSlow:
+			q.discardLocked()
+			q.closed = true
+			q.err = ErrSlowConsumer
+			q.mu.Unlock()
+			atomic.AddUint64(q.dropped, 1)
+			q.closeRaw()
+			return ErrSlowConsumer
+		case DropNewest:
+			q.slow = true
+			q.mu.Unlock()
+			atomic.AddUint64(q.dropped, 1)
+			return ErrSlowConsumer
+		default:
+			for len(q.business) > 0 && q.bytes+len(b) > q.config.HighWatermark {
+				q.bytes -= len(q.business[0])
+				q.business[0] = nil
+				q.business = q.business[1:]
+				atomic.AddUint64(q.dropped, 1)
+			}
+		}
+	}
+	if p == PriorityControl {
+		q.control = append(q.control, b)
+	} else {
+		q.business = append(q.business, b)
+	}
+	q.bytes += len(b)
+	if q.bytes > q.config.HighWatermark {
+		q.slow = true
+	}
+	if !q.writing {
+		q.writing = true
+		go q.flush()
+	}
+	q.mu.Unlock()
+	return nil
+}
+
+// flush 写出队列中的消息, 队列为空时退出, 空闲连接不占用协程
+func (q *outQueue) flush() {
+	for {
+		q.mu.Lock()
+		var b []byte
+		if len(q.control) > 0 {
+			b = q.control[0]
+			q.control[0] = nil
+			q.control = q.control[1:]
+		} else if len(q.business) > 0 {
+			b = q.business[0]
+			q.business[0] = nil
+			q.business = q.business[1:]
+		} else {
+			q.writing = false
+			closing := q.closing && !q.closed
+			if closing {
+				q.closed = true
+			}
+			fn := q.closeFn
+			q.mu.Unlock()
+			if closing {
+				q.closeOnce.Do(fn)
+			}
+			return
+		}
+		q.bytes -= len(b)
+		if q.slow && q.bytes <= q.config.LowWatermark {
+			q.slow = false
+		}
+		q.mu.Unlock()
+		if err := q.raw.Write(b); err != nil {
+			q.mu.Lock()
+			q.err = err
+			q.discardLocked()
+			q.closed = true
+			q.writing = false
+			q.mu.Unlock()
+			q.closeRaw()
+			return
+		}
+	}
+}
+
+// close 队列写完后执行fn关闭连接
+func (q *outQueue) close(fn func()) {
+	q.mu.Lock()
+	if q.closing {
+		q.mu.Unlock()
+		return
+	}
+	if q.closed || !q.writing {
+		q.closed = true
+		q.mu.Unlock()
+		q.closeOnce.Do(fn)
+		return
+	}
+	q.closing = true
+	q.closeFn = fn
+	q.mu.Unlock()
+	time.AfterFunc(q.config.FlushTimeout, func() {
+		q.closeOnce.Do(fn)
+	})
+}
+
+// discard 连接已由引擎关闭, 丢弃未写出的消息
+func (q *outQueue) discard() {
+	q.mu.Lock()
+	q.closed = true
+	q.discardLocked()
+	q.mu.Unlock()
+}
+
+func (q *outQueue) discardLocked() {
+	q.control = nil
+	q.business = nil
+	q.bytes = 0
+	q.slow = false
+}
+
+func (q *outQueue) closeRaw() {
+	q.closeOnce.Do(q.raw.Close)
+}
+
+// OutboundQueued 返回连接出站队列中排队的消息数和字节数, 没有出站队列时为0
+func OutboundQueued(c Conn) (n, bytes int) {
+	if qc, ok := c.(*queuedConn); ok {
+		qc.q.mu.Lock()
+		defer qc.q.mu.Unlock()
+		return len(qc.q.control) + len(qc.q.business), qc.q.bytes
+	}
+	return
+}
@@ -0,0 +1,181 @@
+package socket
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type slowConn struct {
+	ctx      *ConnContext
+	release  chan struct{}
+	entered  chan struct{} // 每次进入Write时通知
+	closedCh chan struct{}
+	mu       sync.Mutex
+	written  []string
+	closed   bool
+}
+
+func newSlowConn() *slowConn {
+	return &slowConn{
+		ctx:      NewContext(),
+		release:  make(chan struct{}),
+		entered:  make(chan struct{}, 16),
+		closedCh: make(chan struct{}),
+	}
+}
+
+func (c *slowConn) Fd() int               { return 1 }
+func (c *slowConn) Context() *ConnContext { return c.ctx }
+func (c *slowConn) Read() ([]byte, error) { return nil, nil }
+func (c *slowConn) Write(b []byte) error {
+	select {
+	case c.entered <- struct{}{}:
+	default:
+	}
+	<-c.release
+	c.mu.Lock()
+	c.written = append(c.written, string(b))
+	c.mu.Unlock()
+	return nil
+}
+func (c *slowConn) Close() {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.closedCh)
+	}
+	c.mu.Unlock()
+}
+func (c *slowConn) LocalAddr() net.Addr  { return nil }
+func (c *slowConn) RemoteAddr() net.Addr { return nil }
+
+func (c *slowConn) result() ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.written...), c.closed
+}
+
+// waitBlocked 等待写出协程进入Write并阻塞
+func waitBlocked(t *testing.T, c *slowConn) {
+	t.Helper()
+	select {
+	case <-c.entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("flush goroutine did not enter Write")
+	}
+}
+
+func waitClosed(t *testing.T, c *slowConn) {
+	t.Helper()
+	select {
+	case <-c.closedCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection not closed")
+	}
+}
+
+func waitWritten(c *slowConn, n int) []string {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if w, _ := c.result(); len(w) >= n {
+			return w
+		}
+		time.Sleep(time.Millisecond)
+	}
+	w, _ := c.result()
+	return w
+}
+
+func TestWriteQueuePriority(t *testing.T) {
+	raw := newSlowConn()
+	var dropped uint64
+	c := newQueuedConn(raw, WriteQueueConfig{HighWatermark: 100}, &dropped)
+	_ = c.Write([]byte("b1")) // 写出协程阻塞在b1
+	waitBlocked(t, raw)
+	_ = c.Write([]byte("b2"))
+	_ = c.WritePriority([]byte("c1"), PriorityControl)
+	close(raw.release)
+	w := waitWritten(raw, 3)
+	if len(w) != 3 || w[0] != "b1" || w[1] != "c1" || w[2] != "b2" {
+		t.Errorf("need control before queued business, got %v", w)
+	}
+}
+
+func TestWriteQueueSlowPolicy(t *testing.T) {
+	// DropOldest: 高水位4字节, 阻塞中的b1不计入排队
+	raw := newSlowConn()
+	var dropped uint64
+	c := newQueuedConn(raw, WriteQueueConfig{HighWatermark: 4}, &dropped)
+	_ = c.Write([]byte("b1"))
+	waitBlocked(t, raw)
+	for _, m := range []string{"b2", "b3", "b4"} {
+		if err := c.Write([]byte(m)); err != nil {
+			t.Error("drop oldest need accept new message")
+			return
+		}
+	}
+	close(raw.release)
+	if w := waitWritten(raw, 3); len(w) != 3 || w[1] != "b3" || w[2] != "b4" || dropped != 1 {
+		t.Errorf("need drop oldest, got %v, dropped %d", w, dropped)
+	}
+
+	// DropNewest
+	raw = newSlowConn()
+	dropped = 0
+	c = newQueuedConn(raw, WriteQueueConfig{HighWatermark: 4, Policy: DropNewest}, &dropped)
+	_ = c.Write([]byte("b1"))
+	waitBlocked(t, raw)
+	_ = c.Write([]byte("b2"))
+	_ = c.Write([]byte("b3"))
+	if err := c.Write([]byte("b4")); err != ErrSlowConsumer {
+		t.Error("drop newest need reject new message")
+		return
+	}
+	if err := c.WritePriority([]byte("c1"), PriorityControl); err != nil {
+		t.Error("control message need not be dropped")
+		return
+	}
+	close(raw.release)
+	if w := waitWritten(raw, 4); len(w) != 4 || w[1] != "c1" || w[3] != "b3" {
+		t.Errorf("need drop newest, got %v", w)
+	}
+
+	// CloseSlow
+	raw = newSlowConn()
+	c = newQueuedConn(raw, WriteQueueConfig{HighWatermark: 4, Policy: CloseSlow}, &dropped)
+	_ = c.Write([]byte("b1"))
+	waitBlocked(t, raw)
+	_ = c.Write([]byte("b2"))
+	_ = c.Write([]byte("b3"))
+	if err := c.Write([]byte("b4")); err != ErrSlowConsumer {
+		t.Error("close slow need reject new message")
+		return
+	}
+	if _, closed := raw.result(); !closed {
+		t.Error("close slow need close connection")
+	}
+	if err := c.Write([]byte("b5")); err != ErrSlowConsumer {
+		t.Error("closed queue need reject writes")
+	}
+	close(raw.release)
+}
+
+func TestWriteQueueCloseFlush(t *testing.T) {
+	raw := newSlowConn()
+	var dropped uint64
+	c := newQueuedConn(raw, WriteQueueConfig{HighWatermark: 100}, &dropped)
+	_ = c.WritePriority([]byte("kicked"), PriorityControl)
+	c.Close()
+	if _, closed := raw.result(); closed {
+		t.Error("need flush before close")
+		return
+	}
+	close(raw.release)
+	w := waitWritten(raw, 1)
+	waitClosed(t, raw)
+	if _, closed := raw.result(); len(w) != 1 || !closed {
+		t.Errorf("need close after flush, got %v", w)
+	}
+}
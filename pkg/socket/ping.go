@@ -0,0 +1,19 @@
+package socket
+
+import "errors"
+
+var ErrPingUnsupported = errors.New("protocol ping not supported")
+
+// Pinger 支持协议层心跳探测的连接(如websocket的ping帧), 不支持时返回ErrPingUnsupported
+// 引擎收到协议层的pong时调用连接上下文的Pong
+type Pinger interface {
+	Ping(payload []byte) error
+}
+
+// Ping 越过出站队列直接发送协议层的ping
+func (c *queuedConn) Ping(payload []byte) error {
+	if p, ok := c.Conn.(Pinger); ok {
+		return p.Ping(payload)
+	}
+	return ErrPingUnsupported
+}
@@ -5,8 +5,11 @@ import (
 	"github.com/obnahsgnaw/rpc/pkg/rpcclient"
 	"github.com/obnahsgnaw/socketgateway/pkg/group"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"github.com/obnahsgnaw/socketgateway/pkg/topic"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Server ---> event ---> tcp engine
@@ -17,11 +20,14 @@ type Engine interface {
 }
 
 type Config struct {
-	MultiCore bool
-	Keepalive uint
-	NoDelay   bool
-	Ticker    bool
-	ReuseAddr bool
+	MultiCore  bool
+	Keepalive  uint
+	NoDelay    bool
+	Ticker     bool
+	ReuseAddr  bool
+	Admission  *AdmissionConfig
+	SizeLimit  SizeLimit
+	WriteQueue *WriteQueueConfig // 为nil时直接写入连接
 }
 
 // Server 服务
@@ -32,11 +38,18 @@ type Server struct {
 	event        Event
 	config       *Config
 	groups       *group.Groups
+	topics       *topic.Subscriptions
 	engine       Engine
 	connections  sync.Map // map[int]Conn
 	connIdBinds  *IDManager
 	relatedBinds *IDManager
 	watchClient  *rpcclient.Manager
+	admission    *admission
+	presenceMu   sync.RWMutex
+	presenceFns  []func(id ConnId, present bool)
+	outDropped   uint64
+	inflight     int64
+	draining     int32
 }
 
 // New return a Server
@@ -48,15 +61,29 @@ func New(ctx context.Context, t sockettype.SocketType, p int, e Engine, event Ev
 		engine:       e,
 		config:       c,
 		groups:       group.New(),
+		topics:       topic.New(),
 		watchClient:  watchClient,
 		connIdBinds:  newIDManager(),
 		relatedBinds: newIDManager(),
 	}
+	if c != nil {
+		s.admission = newAdmission(c.Admission)
+	} else {
+		s.admission = newAdmission(nil)
+	}
 	s.event = newCountedEvent(s, event)
 
 	return s
 }
 
+// SizeLimit 返回包大小限制
+func (s *Server) SizeLimit() SizeLimit {
+	if s.config == nil {
+		return SizeLimit{}
+	}
+	return s.config.SizeLimit
+}
+
 func (s *Server) Context() context.Context {
 	return s.ctx
 }
@@ -85,6 +112,11 @@ func (s *Server) Groups() *group.Groups {
 	return s.groups
 }
 
+// Topics 返回连接对主题的订阅
+func (s *Server) Topics() *topic.Subscriptions {
+	return s.topics
+}
+
 func (s *Server) Start() error {
 	return s.engine.Run(s.ctx, s, s.event, s.typ, s.port, s.config)
 }
@@ -109,7 +141,9 @@ func (s *Server) Stop() {
 func (s *Server) BindId(c Conn, id ConnId) {
 	if id.Type != "" && id.Id != "" && c.Fd() > 0 {
 		c.Context().bind(id)
-		s.connIdBinds.Add(id.String(), c.Fd())
+		if s.connIdBinds.Add(id.String(), c.Fd()) {
+			s.presenceChanged(id, true)
+		}
 	}
 }
 
@@ -131,7 +165,41 @@ func (s *Server) UnbindTypedId(c Conn, typ string) {
 }
 
 func (s *Server) delIdFd(id ConnId, fd int) {
-	s.connIdBinds.Del(id.String(), fd)
+	if s.connIdBinds.Del(id.String(), fd) {
+		s.presenceChanged(id, false)
+	}
+}
+
+// ListenPresence 监听绑定id在当前服务上的出现与消失
+func (s *Server) ListenPresence(fn func(id ConnId, present bool)) {
+	if fn != nil {
+		s.presenceMu.Lock()
+		s.presenceFns = append(s.presenceFns, fn)
+		s.presenceMu.Unlock()
+	}
+}
+
+func (s *Server) presenceChanged(id ConnId, present bool) {
+	s.presenceMu.RLock()
+	defer s.presenceMu.RUnlock()
+	for _, fn := range s.presenceFns {
+		fn(id, present)
+	}
+}
+
+// Presences 返回当前服务上所有已绑定的id
+func (s *Server) Presences() (list []ConnId) {
+	seen := make(map[ConnId]struct{})
+	s.RangeConnections(func(c Conn) bool {
+		c.Context().RangeId(func(id ConnId) {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				list = append(list, id)
+			}
+		})
+		return true
+	})
+	return
 }
 
 func (s *Server) GetFdConn(fd int) Conn {
@@ -147,7 +215,7 @@ func (s *Server) GetIdConn(id ConnId) (list []Conn) {
 		if c := s.GetFdConn(fd); c != nil {
 			list = append(list, c)
 		} else {
-			s.connIdBinds.Del(id.String(), fd)
+			s.delIdFd(id, fd)
 		}
 	}
 	return
@@ -159,15 +227,38 @@ func (s *Server) RangeConnections(f func(c Conn) bool) {
 	})
 }
 
-func (s *Server) addConn(c Conn) {
+// addConn 保存连接, 开启出站队列时返回加上队列的连接
+func (s *Server) addConn(c Conn) Conn {
 	if c.Fd() > 0 {
+		if s.config != nil && s.config.WriteQueue != nil && s.config.WriteQueue.HighWatermark > 0 {
+			if w, ok := c.(DirectWriter); !ok || !w.DirectWrite() {
+				c = newQueuedConn(c, *s.config.WriteQueue, &s.outDropped)
+			}
+		}
 		s.connections.Store(c.Fd(), c)
+		s.admission.add(c)
+	}
+	return c
+}
+
+// conn 返回引擎连接对应的已保存连接
+func (s *Server) conn(c Conn) Conn {
+	if c.Fd() > 0 {
+		if cc, ok := s.connections.Load(c.Fd()); ok && cc.(Conn).Context() == c.Context() {
+			return cc.(Conn)
+		}
 	}
+	return c
 }
 
 func (s *Server) delConn(c Conn) {
 	if c.Fd() > 0 {
-		s.connections.Delete(c.Fd())
+		if cc, ok := s.connections.LoadAndDelete(c.Fd()); ok {
+			if qc, ok1 := cc.(*queuedConn); ok1 {
+				qc.q.discard()
+			}
+			s.admission.del(c)
+		}
 		c.Context().RangeId(func(id ConnId) {
 			s.delIdFd(id, c.Fd())
 		})
@@ -176,10 +267,18 @@ func (s *Server) delConn(c Conn) {
 			g.Leave(c.Fd())
 			return true
 		})
+		// 取消订阅
+		s.topics.UnsubscribeAll(c.Fd())
 	}
 }
 
 func (s *Server) Auth(c Conn, u *AuthUser) {
+	s.AuthWithExpire(c, u, time.Time{})
+}
+
+// AuthWithExpire 设置认证用户及认证过期时间, expireAt为零值时不过期
+func (s *Server) AuthWithExpire(c Conn, u *AuthUser, expireAt time.Time) {
+	c.Context().setAuthExpireAt(expireAt)
 	if u != nil {
 		c.Context().auth(u)
 		s.BindId(c, ConnId{
@@ -201,6 +300,7 @@ func (s *Server) Auth(c Conn, u *AuthUser) {
 func (s *Server) Authenticate(c Conn, u *Authentication) error {
 	if u != nil {
 		c.Context().authenticate(u)
+		s.admission.authenticate(c.Fd(), true)
 		s.BindId(c, ConnId{
 			Id:   u.Id,
 			Type: "TARGET",
@@ -212,6 +312,7 @@ func (s *Server) Authenticate(c Conn, u *Authentication) error {
 	} else {
 		u1 := c.Context().Authentication()
 		c.Context().authenticate(u)
+		s.admission.authenticate(c.Fd(), false)
 		if u1 != nil {
 			s.UnbindId(c, ConnId{
 				Id:   u1.Id,
@@ -253,3 +354,41 @@ func (s *Server) UnbindProxyTarget(target string, fd int) {
 func (s *Server) QueryProxyTargetBinds(target string) []int {
 	return s.relatedBinds.Get(target)
 }
+
+// Admit 按准入规则检查新连接，不满足时返回原因
+func (s *Server) Admit(c Conn) error {
+	if s.Draining() {
+		return ErrDraining
+	}
+	return s.admission.admit(c)
+}
+
+// SetRealIp 设置连接的真实来源ip(如proxy protocol解析得到)，并按真实ip检查单ip限制, 只能设置一次
+func (s *Server) SetRealIp(c Conn, ip string) error {
+	if c.Context().RealIp() != "" {
+		return ErrRealIpSet
+	}
+	c.Context().setRealIp(ip)
+	if c.Fd() <= 0 {
+		return nil
+	}
+	if _, ok := s.connections.Load(c.Fd()); !ok {
+		return nil
+	}
+	return s.admission.admitRealIp(c, ip)
+}
+
+// ConnectionNum 当前连接数
+func (s *Server) ConnectionNum() int {
+	return int(atomic.LoadInt64(&s.admission.total))
+}
+
+// OutboundDropped 出站队列因慢消费者丢弃的消息数
+func (s *Server) OutboundDropped() uint64 {
+	return atomic.LoadUint64(&s.outDropped)
+}
+
+// IpConnectionNum 来源ip的当前连接数
+func (s *Server) IpConnectionNum(ip string) int {
+	return s.admission.ipCount(ip)
+}
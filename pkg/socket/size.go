@@ -0,0 +1,40 @@
+package socket
+
+import "errors"
+
+// DefaultMaxFrame 网关默认的单帧最大字节数
+const DefaultMaxFrame = 4 << 20
+
+// SizeLimit 包大小限制, 各项为0时不限制
+type SizeLimit struct {
+	MaxFrame   int // 单帧的最大字节数: ws帧, tcp等流式连接中未拆完的包, http请求体, mqtt消息
+	MaxBuffer  int // 拆包/分片重组缓存的最大字节数
+	MaxPayload int // 解密后业务包的最大字节数
+}
+
+// CheckFrame 帧长度超过MaxFrame时返回ErrFrameTooLarge
+func (l SizeLimit) CheckFrame(n int) error {
+	if l.MaxFrame > 0 && n > l.MaxFrame {
+		return ErrFrameTooLarge
+	}
+	return nil
+}
+
+// CheckBuffer 缓存长度超过MaxBuffer时返回ErrBufferTooLarge
+func (l SizeLimit) CheckBuffer(n int) error {
+	if l.MaxBuffer > 0 && n > l.MaxBuffer {
+		return ErrBufferTooLarge
+	}
+	return nil
+}
+
+var (
+	ErrFrameTooLarge   = errors.New("frame too large")
+	ErrBufferTooLarge  = errors.New("reassembly buffer too large")
+	ErrPayloadTooLarge = errors.New("payload too large")
+)
+
+// IsSizeLimitErr 是否为超出包大小限制的错误
+func IsSizeLimitErr(err error) bool {
+	return errors.Is(err, ErrFrameTooLarge) || errors.Is(err, ErrBufferTooLarge) || errors.Is(err, ErrPayloadTooLarge)
+}
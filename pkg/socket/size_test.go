@@ -0,0 +1,31 @@
+package socket
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSizeLimitCheck(t *testing.T) {
+	l := SizeLimit{MaxFrame: 10, MaxBuffer: 20}
+	cases := []struct {
+		name  string
+		check func(int) error
+		n     int
+		want  error
+	}{
+		{"frame ok", l.CheckFrame, 10, nil},
+		{"frame too large", l.CheckFrame, 11, ErrFrameTooLarge},
+		{"buffer ok", l.CheckBuffer, 20, nil},
+		{"buffer too large", l.CheckBuffer, 21, ErrBufferTooLarge},
+		{"frame unlimited", SizeLimit{}.CheckFrame, 1 << 30, nil},
+		{"buffer unlimited", SizeLimit{}.CheckBuffer, 1 << 30, nil},
+	}
+	for _, c := range cases {
+		if err := c.check(c.n); !errors.Is(err, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.want)
+		}
+	}
+	if !IsSizeLimitErr(ErrFrameTooLarge) || IsSizeLimitErr(errors.New("x")) {
+		t.Error("IsSizeLimitErr mismatch")
+	}
+}
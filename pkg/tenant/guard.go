@@ -0,0 +1,121 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"strconv"
+	"sync/atomic"
+)
+
+// MetadataKey 调用方在grpc metadata中声明租户(公司)id的key
+const MetadataKey = "company_id"
+
+var (
+	ErrUndeclared = errors.New("tenant isolation: caller company id not declared")
+	ErrInvalid    = errors.New("tenant isolation: caller company id invalid")
+)
+
+// Violation 一次跨租户投递的审计信息
+type Violation struct {
+	Method  string // 调用的方法
+	RqId    string
+	Cid     uint32 // 调用方声明的公司id
+	ConnCid uint32 // 目标连接的公司id
+	Fd      int
+	Target  string // 调用方指定的目标, 如组名、id
+}
+
+// Guard 多租户隔离, 拒绝向公司id与调用方声明不一致的连接投递, nil表示未开启
+type Guard struct {
+	audit      func(Violation)
+	violations uint64
+}
+
+// New audit为违规审计回调
+func New(audit func(Violation)) *Guard {
+	return &Guard{audit: audit}
+}
+
+// Enabled 是否开启了隔离
+func (g *Guard) Enabled() bool {
+	return g != nil
+}
+
+// Parse 解析调用方声明的公司id, 开启隔离时必须声明, 0视为未声明
+func (g *Guard) Parse(values []string) (uint32, error) {
+	if !g.Enabled() {
+		return 0, nil
+	}
+	if len(values) == 0 || values[0] == "" {
+		return 0, ErrUndeclared
+	}
+	cid, err := strconv.ParseUint(values[0], 10, 32)
+	if err != nil {
+		return 0, ErrInvalid
+	}
+	if cid == 0 {
+		return 0, ErrUndeclared
+	}
+	return uint32(cid), nil
+}
+
+// Allowed 检查连接是否属于调用方声明的租户, 不属于时记录审计, v中的Cid、ConnCid、Fd由此填充
+// 公司id为0表示没有租户, 不与任何连接匹配
+func (g *Guard) Allowed(cid uint32, c socket.Conn, v Violation) bool {
+	if !g.Enabled() || c == nil {
+		return true
+	}
+	connCid := ConnCid(c)
+	if cid != 0 && connCid == cid {
+		return true
+	}
+	atomic.AddUint64(&g.violations, 1)
+	if g.audit != nil {
+		v.Cid = cid
+		v.ConnCid = connCid
+		v.Fd = c.Fd()
+		g.audit(v)
+	}
+	return false
+}
+
+// Filter 过滤出属于调用方租户的连接
+func (g *Guard) Filter(cid uint32, list []socket.Conn, v Violation) []socket.Conn {
+	if !g.Enabled() {
+		return list
+	}
+	var allowed []socket.Conn
+	for _, c := range list {
+		if g.Allowed(cid, c, v) {
+			allowed = append(allowed, c)
+		}
+	}
+	return allowed
+}
+
+// Violations 返回累计的违规次数
+func (g *Guard) Violations() uint64 {
+	if !g.Enabled() {
+		return 0
+	}
+	return atomic.LoadUint64(&g.violations)
+}
+
+type cidKey struct{}
+
+// WithCid 声明网关自身发起的投递所属的公司id, 转发到其他网关时随metadata携带
+func WithCid(ctx context.Context, cid uint32) context.Context {
+	return context.WithValue(ctx, cidKey{}, cid)
+}
+
+// CidFrom 返回WithCid声明的公司id, 0为未声明
+func CidFrom(ctx context.Context) uint32 {
+	cid, _ := ctx.Value(cidKey{}).(uint32)
+	return cid
+}
+
+// ConnCid 返回连接所属的公司id, 优先authenticate的公司id, 其次认证用户的公司id
+func ConnCid(c socket.Conn) uint32 {
+	return c.Context().Cid()
+}
@@ -0,0 +1,98 @@
+package tenant
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"net"
+	"testing"
+)
+
+type conn struct {
+	fd  int
+	ctx *socket.ConnContext
+}
+
+func (c *conn) Fd() int                      { return c.fd }
+func (c *conn) Context() *socket.ConnContext { return c.ctx }
+func (c *conn) Read() ([]byte, error)        { return nil, nil }
+func (c *conn) Write([]byte) error           { return nil }
+func (c *conn) Close()                       {}
+func (c *conn) LocalAddr() net.Addr          { return nil }
+func (c *conn) RemoteAddr() net.Addr         { return nil }
+
+func newConn(s *socket.Server, fd int, cid uint32) socket.Conn {
+	c := &conn{fd: fd, ctx: socket.NewContext()}
+	_ = s.Authenticate(c, &socket.Authentication{Type: "device", Id: "d", Sn: "d", Cid: cid})
+	return c
+}
+
+func TestGuard(t *testing.T) {
+	s := socket.New(context.Background(), sockettype.TCP, 0, nil, nil, nil, nil)
+	var audited []Violation
+	g := New(func(v Violation) {
+		audited = append(audited, v)
+	})
+
+	if _, err := g.Parse(nil); err != ErrUndeclared {
+		t.Error("need undeclared error")
+		return
+	}
+	if _, err := g.Parse([]string{"abc"}); err != ErrInvalid {
+		t.Error("need invalid error")
+		return
+	}
+	cid, err := g.Parse([]string{"2"})
+	if err != nil || cid != 2 {
+		t.Error("parse failed")
+		return
+	}
+
+	list := []socket.Conn{newConn(s, 1, 1), newConn(s, 2, 2), newConn(s, 3, 2)}
+	allowed := g.Filter(cid, list, Violation{Method: "test", Target: "d"})
+	if len(allowed) != 2 || allowed[0].Fd() != 2 {
+		t.Error("filter failed")
+		return
+	}
+	if len(audited) != 1 || audited[0].Fd != 1 || audited[0].Cid != 2 || audited[0].ConnCid != 1 || audited[0].Method != "test" {
+		t.Error("audit failed")
+		return
+	}
+	if g.Violations() != 1 {
+		t.Error("violations count failed")
+		return
+	}
+
+	var disabled *Guard
+	if !disabled.Allowed(2, list[0], Violation{}) || len(disabled.Filter(2, list, Violation{})) != 3 {
+		t.Error("disabled guard need allow all")
+	}
+}
+
+func TestGuardNoTenant(t *testing.T) {
+	s := socket.New(context.Background(), sockettype.TCP, 0, nil, nil, nil, nil)
+	g := New(nil)
+	if _, err := g.Parse([]string{"0"}); err != ErrUndeclared {
+		t.Error("company id 0 need undeclared error")
+		return
+	}
+	anonymous := &conn{fd: 1, ctx: socket.NewContext()}
+	if g.Allowed(0, anonymous, Violation{}) || g.Allowed(0, newConn(s, 2, 0), Violation{}) {
+		t.Error("company id 0 need match no connection")
+		return
+	}
+	if g.Violations() != 2 {
+		t.Error("violations count failed")
+	}
+}
+
+func TestCidContext(t *testing.T) {
+	ctx := context.Background()
+	if CidFrom(ctx) != 0 {
+		t.Error("need undeclared")
+		return
+	}
+	if CidFrom(WithCid(ctx, 3)) != 3 {
+		t.Error("need declared company id")
+	}
+}
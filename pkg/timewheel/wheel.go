@@ -0,0 +1,262 @@
+package timewheel
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	slotBits = 6
+	slotNum  = 1 << slotBits
+	slotMask = slotNum - 1
+	levelNum = 5
+	maxTicks = 1<<(slotBits*levelNum) - 1
+)
+
+// DefaultTick 默认精度
+const DefaultTick = time.Second
+
+// Wheel 分层时间轮, 以tick为精度调度大量定时器, 添加、取消均为O(1), 每个刻度只处理到期的槽
+// 低层每个槽为一个刻度, 高层的槽在低层转完一圈时下沉到低层
+type Wheel struct {
+	tick    time.Duration
+	mu      sync.Mutex
+	now     int64 // 已推进的刻度数
+	levels  [levelNum][slotNum]Timer
+	workers int
+	queue   chan *Timer
+	onPanic func(err, stack string)
+}
+
+// Option 时间轮选项
+type Option func(*Wheel)
+
+// Workers 回调交由n个协程的工作池执行, 队列长度为queue, 队列满时顺延到下一个刻度, 不阻塞时间轮
+// 回调中有io(如写连接)时应开启, 否则回调在时间轮的协程中执行
+func Workers(n, queue int) Option {
+	return func(w *Wheel) {
+		if n <= 0 {
+			return
+		}
+		if queue < n {
+			queue = n
+		}
+		w.workers = n
+		w.queue = make(chan *Timer, queue)
+	}
+}
+
+// PanicHandler 回调panic时的处理, panic总会被恢复, 不影响其他定时器
+func PanicHandler(fn func(err, stack string)) Option {
+	return func(w *Wheel) {
+		w.onPanic = fn
+	}
+}
+
+// Timer 时间轮上的定时器, 未开启工作池时回调在时间轮的协程中执行, 不应阻塞
+type Timer struct {
+	w          *Wheel
+	fn         func()
+	expire     int64
+	prev, next *Timer
+	pending    bool
+	stopped    bool
+}
+
+func New(tick time.Duration, options ...Option) *Wheel {
+	if tick <= 0 {
+		tick = DefaultTick
+	}
+	w := &Wheel{tick: tick}
+	for _, o := range options {
+		o(w)
+	}
+	for i := range w.levels {
+		for j := range w.levels[i] {
+			head := &w.levels[i][j]
+			head.prev, head.next = head, head
+		}
+	}
+	return w
+}
+
+// Tick 返回时间轮的精度
+func (w *Wheel) Tick() time.Duration {
+	return w.tick
+}
+
+// NewTimer 创建未调度的定时器, 通过Reset调度, 回调中可以Reset自身实现周期执行
+func (w *Wheel) NewTimer(fn func()) *Timer {
+	return &Timer{w: w, fn: fn}
+}
+
+// AfterFunc d后执行fn
+func (w *Wheel) AfterFunc(d time.Duration, fn func()) *Timer {
+	t := w.NewTimer(fn)
+	t.Reset(d)
+	return t
+}
+
+// Reset 重新调度为d后执行, 不足一个刻度按一个刻度, 已Stop的定时器返回false
+func (t *Timer) Reset(d time.Duration) bool {
+	w := t.w
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t.stopped {
+		return false
+	}
+	if t.pending {
+		t.unlink()
+	}
+	ticks := int64((d + w.tick - 1) / w.tick)
+	if ticks < 1 {
+		ticks = 1
+	}
+	t.expire = w.now + ticks
+	w.add(t)
+	return true
+}
+
+// Stop 取消并停止定时器, 之后Reset无效, 返回是否取消了未执行的调度
+func (t *Timer) Stop() bool {
+	t.w.mu.Lock()
+	defer t.w.mu.Unlock()
+	t.stopped = true
+	if t.pending {
+		t.unlink()
+		return true
+	}
+	return false
+}
+
+// Run 按精度推进时间轮, 直到ctx结束
+func (w *Wheel) Run(ctx context.Context) {
+	for i := 0; i < w.workers; i++ {
+		go w.work(ctx)
+	}
+	ticker := time.NewTicker(w.tick)
+	defer ticker.Stop()
+	start := time.Now()
+	var n int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			// 回调耗时超过精度时补齐落下的刻度
+			for target := int64(now.Sub(start) / w.tick); n < target; n++ {
+				w.Advance()
+			}
+		}
+	}
+}
+
+// Advance 推进一个刻度并执行到期的定时器
+func (w *Wheel) Advance() {
+	w.mu.Lock()
+	w.now++
+	for lvl := 1; lvl < levelNum; lvl++ {
+		if (w.now>>(slotBits*(lvl-1)))&slotMask != 0 {
+			break
+		}
+		w.cascade(lvl, int((w.now>>(slotBits*lvl))&slotMask))
+	}
+	head := &w.levels[0][w.now&slotMask]
+	var due []*Timer
+	for t := head.next; t != head; {
+		next := t.next
+		if t.expire <= w.now {
+			t.unlink()
+			due = append(due, t)
+		}
+		t = next
+	}
+	w.mu.Unlock()
+	for _, t := range due {
+		if w.queue == nil {
+			w.exec(t)
+			continue
+		}
+		select {
+		case w.queue <- t:
+		default:
+			// 工作池繁忙, 顺延到下一个刻度
+			t.Reset(w.tick)
+		}
+	}
+}
+
+func (w *Wheel) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case t := <-w.queue:
+			w.exec(t)
+		}
+	}
+}
+
+func (w *Wheel) exec(t *Timer) {
+	defer func() {
+		if err := recover(); err != nil && w.onPanic != nil {
+			w.onPanic(fmt.Sprint(err), string(debug.Stack()))
+		}
+	}()
+	t.fn()
+}
+
+// Len 返回已调度的定时器数量
+func (w *Wheel) Len() (n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range w.levels {
+		for j := range w.levels[i] {
+			head := &w.levels[i][j]
+			for t := head.next; t != head; t = t.next {
+				n++
+			}
+		}
+	}
+	return
+}
+
+// cascade 将高层的槽下沉到低层
+func (w *Wheel) cascade(lvl, idx int) {
+	head := &w.levels[lvl][idx]
+	for t := head.next; t != head; {
+		next := t.next
+		t.unlink()
+		w.add(t)
+		t = next
+	}
+}
+
+func (w *Wheel) add(t *Timer) {
+	delta := t.expire - w.now
+	if delta < 0 {
+		t.expire, delta = w.now, 0
+	}
+	if delta > maxTicks {
+		t.expire, delta = w.now+maxTicks, maxTicks
+	}
+	lvl := 0
+	for delta >= 1<<(slotBits*(lvl+1)) {
+		lvl++
+	}
+	head := &w.levels[lvl][(t.expire>>(slotBits*lvl))&slotMask]
+	t.prev, t.next = head.prev, head
+	head.prev.next = t
+	head.prev = t
+	t.pending = true
+}
+
+func (t *Timer) unlink() {
+	t.prev.next = t.next
+	t.next.prev = t.prev
+	t.prev, t.next = nil, nil
+	t.pending = false
+}
@@ -0,0 +1,91 @@
+package timewheel
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWheel(t *testing.T) {
+	w := New(time.Millisecond)
+	fired := make(map[int]int64)
+	for _, ticks := range []int{1, 63, 64, 65, 4095, 4096, 5000, 300000} {
+		ticks := ticks
+		w.AfterFunc(time.Duration(ticks)*time.Millisecond, func() {
+			fired[ticks] = w.now
+		})
+	}
+	cancelled := w.AfterFunc(10*time.Millisecond, func() {
+		t.Error("stopped timer need not fire")
+	})
+	if !cancelled.Stop() || cancelled.Reset(time.Millisecond) {
+		t.Error("need stop and not reset")
+		return
+	}
+	var n int
+	periodic := w.NewTimer(nil)
+	periodic.fn = func() {
+		if n++; n < 3 {
+			periodic.Reset(100 * time.Millisecond)
+		}
+	}
+	periodic.Reset(100 * time.Millisecond)
+	for i := 0; i < 300000; i++ {
+		w.Advance()
+	}
+	for ticks, at := range fired {
+		if int64(ticks) != at {
+			t.Errorf("timer of %d ticks fired at %d", ticks, at)
+		}
+	}
+	if len(fired) != 8 || n != 3 || w.Len() != 0 {
+		t.Errorf("need all fired once, fired=%d, periodic=%d, left=%d", len(fired), n, w.Len())
+	}
+}
+
+func TestWheelWorkers(t *testing.T) {
+	panicked := make(chan string, 1)
+	w := New(time.Millisecond, Workers(1, 1), PanicHandler(func(err, stack string) {
+		panicked <- err
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	release := make(chan struct{})
+	fired := make(chan int, 3)
+	w.AfterFunc(time.Millisecond, func() {
+		panic("boom")
+	})
+	w.AfterFunc(2*time.Millisecond, func() {
+		<-release
+		fired <- 0
+	})
+	// 工作协程阻塞时, 时间轮仍在推进, 溢出的定时器顺延执行
+	for i := 1; i <= 2; i++ {
+		i := i
+		w.AfterFunc(3*time.Millisecond, func() {
+			fired <- i
+		})
+	}
+	select {
+	case err := <-panicked:
+		if err != "boom" {
+			t.Errorf("need boom panic, got %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("need panic recovered")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if w.Len() == 0 {
+		t.Error("need overflowed timers rescheduled while worker blocked")
+	}
+	close(release)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-fired:
+		case <-time.After(time.Second):
+			t.Fatal("need all timers fired")
+		}
+	}
+}
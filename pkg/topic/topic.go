@@ -0,0 +1,163 @@
+package topic
+
+import (
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/mqtt"
+	"sort"
+	"sync"
+)
+
+var ErrLimitExceeded = errors.New("topic subscription limit exceeded")
+
+// Subscriber 匹配主题的订阅
+type Subscriber struct {
+	Fd     int
+	Filter string            // 匹配的主题过滤器
+	Params map[string]string // 过滤器中{param}的值
+}
+
+type filter struct {
+	pattern *mqtt.ParamPattern
+	fds     map[int]struct{}
+}
+
+// Subscriptions 连接对主题的订阅, 主题过滤器支持+、#及{param}通配
+type Subscriptions struct {
+	mu      sync.RWMutex
+	filters map[string]*filter          // filter => subscribers
+	tree    *node                       // 按层级索引的过滤器
+	conns   map[int]map[string]struct{} // fd => filters
+	limit   int
+}
+
+func New() *Subscriptions {
+	return &Subscriptions{
+		filters: make(map[string]*filter),
+		tree:    newNode(),
+		conns:   make(map[int]map[string]struct{}),
+	}
+}
+
+// SetLimit 设置每个连接的最大订阅数, 0为不限制
+func (s *Subscriptions) SetLimit(limit int) {
+	s.mu.Lock()
+	s.limit = limit
+	s.mu.Unlock()
+}
+
+// Subscribe 订阅主题, 已订阅时忽略
+func (s *Subscriptions) Subscribe(fd int, f string) error {
+	s.mu.RLock()
+	ff, ok := s.filters[f]
+	s.mu.RUnlock()
+	var pattern *mqtt.ParamPattern
+	if !ok {
+		var err error
+		if pattern, err = mqtt.NewTopicPattern(f); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subscribed := s.conns[fd]
+	if _, ok = subscribed[f]; ok {
+		return nil
+	}
+	if s.limit > 0 && len(subscribed) >= s.limit {
+		return ErrLimitExceeded
+	}
+	if ff, ok = s.filters[f]; !ok {
+		// 检查后被删除
+		if pattern == nil {
+			var err error
+			if pattern, err = mqtt.NewTopicPattern(f); err != nil {
+				return err
+			}
+		}
+		ff = &filter{pattern: pattern, fds: make(map[int]struct{})}
+		s.filters[f] = ff
+		s.tree.add(f)
+	}
+	ff.fds[fd] = struct{}{}
+	if subscribed == nil {
+		subscribed = make(map[string]struct{})
+		s.conns[fd] = subscribed
+	}
+	subscribed[f] = struct{}{}
+	return nil
+}
+
+// Unsubscribe 取消订阅, 返回是否订阅过
+func (s *Subscriptions) Unsubscribe(fd int, f string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.unsubscribe(fd, f)
+}
+
+func (s *Subscriptions) unsubscribe(fd int, f string) bool {
+	subscribed, ok := s.conns[fd]
+	if !ok {
+		return false
+	}
+	if _, ok = subscribed[f]; !ok {
+		return false
+	}
+	delete(subscribed, f)
+	if len(subscribed) == 0 {
+		delete(s.conns, fd)
+	}
+	if ff, ok1 := s.filters[f]; ok1 {
+		delete(ff.fds, fd)
+		if len(ff.fds) == 0 {
+			delete(s.filters, f)
+			s.tree.remove(f)
+		}
+	}
+	return true
+}
+
+// UnsubscribeAll 取消连接的全部订阅, 用于连接关闭
+func (s *Subscriptions) UnsubscribeAll(fd int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for f := range s.conns[fd] {
+		s.unsubscribe(fd, f)
+	}
+}
+
+// Filters 返回连接订阅的主题过滤器
+func (s *Subscriptions) Filters(fd int) (list []string) {
+	s.mu.RLock()
+	for f := range s.conns[fd] {
+		list = append(list, f)
+	}
+	s.mu.RUnlock()
+	sort.Strings(list)
+	return
+}
+
+// Match 返回订阅了主题的连接, 一个连接的多个过滤器匹配时只返回一次, 按fd排序
+func (s *Subscriptions) Match(topic string) (list []Subscriber) {
+	matched := make(map[int]Subscriber)
+	s.mu.RLock()
+	s.tree.match(topic, func(f string) {
+		for fd := range s.filters[f].fds {
+			// 多个过滤器匹配时取名称最小的, 保证结果稳定
+			if sub, ok := matched[fd]; !ok || f < sub.Filter {
+				matched[fd] = Subscriber{Fd: fd, Filter: f}
+			}
+		}
+	})
+	for fd, sub := range matched {
+		sub.Params = s.filters[sub.Filter].pattern.Parse(topic)
+		matched[fd] = sub
+	}
+	s.mu.RUnlock()
+	for _, sub := range matched {
+		list = append(list, sub)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Fd < list[j].Fd
+	})
+	return
+}
@@ -0,0 +1,69 @@
+package topic
+
+import (
+	"github.com/obnahsgnaw/socketgateway/pkg/mqtt"
+	"testing"
+)
+
+func TestSubscriptions(t *testing.T) {
+	s := New()
+	s.SetLimit(2)
+	if err := s.Subscribe(1, "a/#/b"); err != mqtt.ErrInvalidTopicFilter {
+		t.Error("need invalid filter error")
+		return
+	}
+	_ = s.Subscribe(1, "meter/+/state")
+	_ = s.Subscribe(1, "meter/#")
+	if err := s.Subscribe(1, "other"); err != ErrLimitExceeded {
+		t.Error("need limit error")
+		return
+	}
+	_ = s.Subscribe(2, "meter/{sn}/state")
+	_ = s.Subscribe(3, "meter/{sn}/alarm")
+
+	list := s.Match("meter/m1/state")
+	if len(list) != 2 || list[0].Fd != 1 || list[0].Filter != "meter/#" || list[1].Params["sn"] != "m1" {
+		t.Error("match failed", list)
+		return
+	}
+	if list = s.Match("meter"); len(list) != 1 || list[0].Fd != 1 {
+		t.Error("# need match parent level", list)
+		return
+	}
+
+	s.UnsubscribeAll(1)
+	if list = s.Match("meter/m1/state"); len(list) != 1 || list[0].Fd != 2 {
+		t.Error("unsubscribe all failed", list)
+		return
+	}
+	if !s.Unsubscribe(2, "meter/{sn}/state") || s.Unsubscribe(2, "meter/{sn}/state") || len(s.Filters(3)) != 1 {
+		t.Error("unsubscribe failed")
+	}
+}
+
+func TestTreeMatchesPattern(t *testing.T) {
+	filters := []string{"#", "+", "a", "a/#", "a/+", "a/+/c", "a/{id}/c", "a/b/#", "+/+/+", "+/b/{x}", "a//c", "{x}"}
+	topics := []string{"", "a", "a/", "a/b", "a/b/c", "a//c", "a/b/c/d", "x/b/y", "b", "/"}
+	tree := newNode()
+	for _, f := range filters {
+		tree.add(f)
+	}
+	for _, topic := range topics {
+		got := make(map[string]bool)
+		tree.match(topic, func(f string) {
+			got[f] = true
+		})
+		for _, f := range filters {
+			p, _ := mqtt.NewTopicPattern(f)
+			if p.Match(topic) != got[f] {
+				t.Errorf("filter %q topic %q: pattern %v, tree %v", f, topic, p.Match(topic), got[f])
+			}
+		}
+	}
+	for _, f := range filters {
+		tree.remove(f)
+	}
+	if !tree.empty() {
+		t.Error("need prune empty branches")
+	}
+}
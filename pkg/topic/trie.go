@@ -0,0 +1,123 @@
+package topic
+
+import "strings"
+
+// node 主题过滤器按层级组成的树, 匹配时只遍历与主题各层相符的分支
+type node struct {
+	children map[string]*node    // 普通层级
+	wild     map[string]*node    // +及{param}层级
+	ends     map[string]struct{} // 在此层结束的过滤器
+	hashes   map[string]struct{} // 在此层以#结束的过滤器
+}
+
+func newNode() *node {
+	return &node{}
+}
+
+func isWild(level string) bool {
+	return level == "+" || paramLevel(level)
+}
+
+func paramLevel(level string) bool {
+	return len(level) > 2 && level[0] == '{' && level[len(level)-1] == '}'
+}
+
+// add 添加已校验的过滤器
+func (n *node) add(f string) {
+	levels := strings.Split(f, "/")
+	hash := levels[len(levels)-1] == "#"
+	if hash {
+		levels = levels[:len(levels)-1]
+	}
+	cur := n
+	for _, level := range levels {
+		m := &cur.children
+		if isWild(level) {
+			m = &cur.wild
+		}
+		if *m == nil {
+			*m = make(map[string]*node)
+		}
+		next, ok := (*m)[level]
+		if !ok {
+			next = newNode()
+			(*m)[level] = next
+		}
+		cur = next
+	}
+	if hash {
+		if cur.hashes == nil {
+			cur.hashes = make(map[string]struct{})
+		}
+		cur.hashes[f] = struct{}{}
+	} else {
+		if cur.ends == nil {
+			cur.ends = make(map[string]struct{})
+		}
+		cur.ends[f] = struct{}{}
+	}
+}
+
+// remove 删除过滤器并清理空的分支
+func (n *node) remove(f string) {
+	levels := strings.Split(f, "/")
+	hash := levels[len(levels)-1] == "#"
+	if hash {
+		levels = levels[:len(levels)-1]
+	}
+	n.removeLevels(f, levels, hash)
+}
+
+func (n *node) removeLevels(f string, levels []string, hash bool) {
+	if len(levels) == 0 {
+		if hash {
+			delete(n.hashes, f)
+		} else {
+			delete(n.ends, f)
+		}
+		return
+	}
+	m := n.children
+	if isWild(levels[0]) {
+		m = n.wild
+	}
+	next, ok := m[levels[0]]
+	if !ok {
+		return
+	}
+	next.removeLevels(f, levels[1:], hash)
+	if next.empty() {
+		delete(m, levels[0])
+	}
+}
+
+func (n *node) empty() bool {
+	return len(n.children) == 0 && len(n.wild) == 0 && len(n.ends) == 0 && len(n.hashes) == 0
+}
+
+// match 将匹配主题的过滤器传给fn, 一个过滤器只传一次
+func (n *node) match(topic string, fn func(f string)) {
+	n.matchLevels(strings.Split(topic, "/"), fn)
+}
+
+func (n *node) matchLevels(levels []string, fn func(f string)) {
+	// #匹配剩余的所有层级, 包括没有剩余层级
+	for f := range n.hashes {
+		fn(f)
+	}
+	if len(levels) == 0 {
+		for f := range n.ends {
+			fn(f)
+		}
+		return
+	}
+	if next, ok := n.children[levels[0]]; ok {
+		next.matchLevels(levels[1:], fn)
+	}
+	for level, next := range n.wild {
+		// {param}不匹配空层级
+		if level == "+" || levels[0] != "" {
+			next.matchLevels(levels[1:], fn)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package transcode
+
+import (
+	"errors"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"strconv"
+	"sync"
+)
+
+var ErrNotRegistered = errors.New("transcode: action message not registered")
+
+// Registry 按action记录其数据的protobuf描述, 用于在proto与json编码之间转换
+type Registry struct {
+	mu        sync.RWMutex
+	messages  map[codec.ActionId]protoreflect.MessageDescriptor
+	marshal   protojson.MarshalOptions
+	unmarshal protojson.UnmarshalOptions
+}
+
+func New() *Registry {
+	return &Registry{
+		messages:  make(map[codec.ActionId]protoreflect.MessageDescriptor),
+		marshal:   protojson.MarshalOptions{UseProtoNames: true},
+		unmarshal: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+}
+
+// Register 注册action数据的消息描述
+func (r *Registry) Register(id codec.ActionId, md protoreflect.MessageDescriptor) {
+	r.mu.Lock()
+	r.messages[id] = md
+	r.mu.Unlock()
+}
+
+// RegisterMessage 注册action数据的消息
+func (r *Registry) RegisterMessage(id codec.ActionId, m proto.Message) {
+	r.Register(id, m.ProtoReflect().Descriptor())
+}
+
+// RegisterFiles 从文件描述集中注册action数据的消息, messages为action id到消息全名的映射
+// 文件描述集中未包含的依赖从已编译进网关的文件中查找
+func (r *Registry) RegisterFiles(set *descriptorpb.FileDescriptorSet, messages map[codec.ActionId]string) error {
+	files := &protoregistry.Files{}
+	res := resolver{files}
+	for _, fd := range set.GetFile() {
+		if _, err := files.FindFileByPath(fd.GetName()); err == nil {
+			continue
+		}
+		f, err := protodesc.NewFile(fd, res)
+		if err != nil {
+			return err
+		}
+		if err = files.RegisterFile(f); err != nil {
+			return err
+		}
+	}
+	resolved := make(map[codec.ActionId]protoreflect.MessageDescriptor, len(messages))
+	for id, name := range messages {
+		d, err := res.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return errors.New("transcode: message " + name + " of action " + strconv.Itoa(int(id)) + " not found")
+		}
+		md, ok := d.(protoreflect.MessageDescriptor)
+		if !ok {
+			return errors.New("transcode: " + name + " is not a message")
+		}
+		resolved[id] = md
+	}
+	for id, md := range resolved {
+		r.Register(id, md)
+	}
+	return nil
+}
+
+// Unregister 取消注册
+func (r *Registry) Unregister(ids ...codec.ActionId) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		delete(r.messages, id)
+	}
+}
+
+func (r *Registry) message(id codec.ActionId) (protoreflect.MessageDescriptor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	md, ok := r.messages[id]
+	return md, ok
+}
+
+// Transcodable action的数据是否可以转换
+func (r *Registry) Transcodable(id codec.ActionId) bool {
+	_, ok := r.message(id)
+	return ok
+}
+
+// ToProto 将json数据转换为proto数据
+func (r *Registry) ToProto(id codec.ActionId, data []byte) ([]byte, error) {
+	md, ok := r.message(id)
+	if !ok {
+		return nil, ErrNotRegistered
+	}
+	m := dynamicpb.NewMessage(md)
+	if len(data) > 0 {
+		if err := r.unmarshal.Unmarshal(data, m); err != nil {
+			return nil, err
+		}
+	}
+	return proto.Marshal(m)
+}
+
+// ToJson 将proto数据转换为json数据
+func (r *Registry) ToJson(id codec.ActionId, data []byte) ([]byte, error) {
+	md, ok := r.message(id)
+	if !ok {
+		return nil, ErrNotRegistered
+	}
+	m := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return r.marshal.Marshal(m)
+}
+
+// resolver 优先从注册的文件中查找, 其次从已编译进网关的文件中查找
+type resolver struct {
+	files *protoregistry.Files
+}
+
+func (r resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if f, err := r.files.FindFileByPath(path); err == nil {
+		return f, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
@@ -0,0 +1,72 @@
+package transcode
+
+import (
+	"github.com/obnahsgnaw/socketutil/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"testing"
+)
+
+func noticeFiles() *descriptorpb.FileDescriptorSet {
+	return &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("notice/v1/notice.proto"),
+			Package: proto.String("notice.v1"),
+			Syntax:  proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("Notice"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("title"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), JsonName: proto.String("title")},
+					{Name: proto.String("alarm_level"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), JsonName: proto.String("alarmLevel")},
+				},
+			}},
+		}},
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := New()
+	if err := r.RegisterFiles(noticeFiles(), map[codec.ActionId]string{101: "notice.v1.Unknown"}); err == nil {
+		t.Error("need unknown message error")
+		return
+	}
+	if err := r.RegisterFiles(noticeFiles(), map[codec.ActionId]string{101: "notice.v1.Notice"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if !r.Transcodable(101) || r.Transcodable(102) {
+		t.Error("transcodable failed")
+		return
+	}
+
+	pb, err := r.ToProto(101, []byte(`{"title":"maintenance","alarm_level":3}`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	js, err := r.ToJson(101, pb)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pb1, err := r.ToProto(101, js)
+	if err != nil || !proto.Equal(mustDecode(t, r, pb), mustDecode(t, r, pb1)) {
+		t.Error("round trip failed", string(js))
+		return
+	}
+
+	r.Unregister(101)
+	if _, err = r.ToJson(101, pb); err != ErrNotRegistered {
+		t.Error("need not registered error")
+	}
+}
+
+func mustDecode(t *testing.T, r *Registry, data []byte) proto.Message {
+	md, _ := r.message(101)
+	m := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(data, m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
@@ -0,0 +1,100 @@
+package action
+
+import (
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"path"
+)
+
+// Rule action的访问控制规则
+type Rule struct {
+	Public bool                // 无需认证即可访问
+	Types  []string            // 允许的Authentication.Type, 为空不限制
+	Attrs  map[string][]string // AuthUser.Attr需满足的条件, 如role、company_id, 值需在列表中
+}
+
+type patternRule struct {
+	pattern string
+	rule    Rule
+}
+
+// Allowed 检查认证信息和用户是否满足规则
+func (r Rule) Allowed(a *socket.Authentication, u *socket.AuthUser) bool {
+	if r.Public {
+		return true
+	}
+	if len(r.Types) > 0 {
+		if a == nil || !inStrings(r.Types, a.Type) {
+			return false
+		}
+	}
+	for key, values := range r.Attrs {
+		if u == nil {
+			return false
+		}
+		v, ok := u.Attr[key]
+		if !ok || (len(values) > 0 && !inStrings(values, v)) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetActionRule 设置action id的访问规则
+func (m *Manager) SetActionRule(id codec.ActionId, rule Rule) {
+	m.rules.Store(id, rule)
+}
+
+// SetPatternRule 设置action name匹配模式(path.Match语法, 如 user.*)的访问规则, 按设置顺序匹配
+func (m *Manager) SetPatternRule(pattern string, rule Rule) {
+	m.ruleMu.Lock()
+	defer m.ruleMu.Unlock()
+	for i, pr := range m.patternRules {
+		if pr.pattern == pattern {
+			m.patternRules[i].rule = rule
+			return
+		}
+	}
+	m.patternRules = append(m.patternRules, patternRule{pattern: pattern, rule: rule})
+}
+
+// DelActionRule 删除action id的访问规则
+func (m *Manager) DelActionRule(id codec.ActionId) {
+	m.rules.Delete(id)
+}
+
+// DelPatternRule 删除匹配模式的访问规则
+func (m *Manager) DelPatternRule(pattern string) {
+	m.ruleMu.Lock()
+	defer m.ruleMu.Unlock()
+	for i, pr := range m.patternRules {
+		if pr.pattern == pattern {
+			m.patternRules = append(m.patternRules[:i], m.patternRules[i+1:]...)
+			return
+		}
+	}
+}
+
+// Rule 返回action的访问规则, id规则优先于匹配模式规则
+func (m *Manager) Rule(act codec.Action) (Rule, bool) {
+	if r, ok := m.rules.Load(act.Id); ok {
+		return r.(Rule), true
+	}
+	m.ruleMu.RLock()
+	defer m.ruleMu.RUnlock()
+	for _, pr := range m.patternRules {
+		if ok, _ := path.Match(pr.pattern, act.Name); ok {
+			return pr.rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+func inStrings(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package action
+
+import (
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"testing"
+)
+
+func TestRule(t *testing.T) {
+	m := NewManager()
+	m.SetActionRule(1, Rule{Public: true})
+	m.SetPatternRule("admin.*", Rule{Types: []string{"user"}, Attrs: map[string][]string{"role": {"admin"}}})
+
+	if r, ok := m.Rule(codec.NewAction(1, "ping")); !ok || !r.Allowed(nil, nil) {
+		t.Error("public action need allowed")
+		return
+	}
+	if _, ok := m.Rule(codec.NewAction(2, "user.info")); ok {
+		t.Error("no rule need not matched")
+		return
+	}
+	r, ok := m.Rule(codec.NewAction(3, "admin.kick"))
+	if !ok {
+		t.Error("pattern rule need matched")
+		return
+	}
+	a := &socket.Authentication{Type: "user"}
+	if r.Allowed(a, &socket.AuthUser{Attr: map[string]string{"role": "guest"}}) {
+		t.Error("role guest need denied")
+		return
+	}
+	if r.Allowed(&socket.Authentication{Type: "device"}, &socket.AuthUser{Attr: map[string]string{"role": "admin"}}) {
+		t.Error("type device need denied")
+		return
+	}
+	if !r.Allowed(a, &socket.AuthUser{Attr: map[string]string{"role": "admin"}}) {
+		t.Error("admin need allowed")
+	}
+}
@@ -30,6 +30,10 @@ type Manager struct {
 	rfFn                func()
 	debug               bool
 	l                   func(string)
+	rules               sync.Map // action-id, Rule
+	ruleMu              sync.RWMutex
+	patternRules        []patternRule
+	transcoder          Transcoder
 }
 
 type actionHandler struct {
@@ -46,6 +50,13 @@ type RemoteHandler interface {
 	Call(rqId, serverHost, gateway, format string, c socket.Conn, id codec.ActionId, data []byte) (respAction codec.Action, respData []byte, err error)
 }
 
+// Transcoder action数据在proto与json编码之间的转换, 开启后handler只需处理proto数据
+type Transcoder interface {
+	Transcodable(id codec.ActionId) bool
+	ToProto(id codec.ActionId, data []byte) ([]byte, error)
+	ToJson(id codec.ActionId, data []byte) ([]byte, error)
+}
+
 type serverSet map[string][2]string // [server]action-name
 
 type actionSet map[codec.ActionId]string // [action-id]action-name
@@ -304,10 +315,43 @@ func (m *Manager) Dispatch(c socket.Conn, rqId string, b codec.DataBuilder, acti
 		err = errors.New("action manager error: no set remote action handler")
 		return
 	}
-	respAction, respData, err = m.remoteHandler.Call(rqId, s, m.gateway.String(), b.Name().String(), c, actionId, actionData)
+	// 非proto编码的连接, 请求数据可转换时转换为proto交由handler处理, 响应再转换回来
+	format := b.Name()
+	if format != codec.Proto && m.transcoder != nil && m.transcoder.Transcodable(actionId) {
+		if actionData, err = m.transcoder.ToProto(actionId, actionData); err != nil {
+			err = errors.New("action manager error: transcode request failed, err=" + err.Error())
+			return
+		}
+		respAction, respData, err = m.remoteHandler.Call(rqId, s, m.gateway.String(), codec.Proto.String(), c, actionId, actionData)
+		if err == nil {
+			respData, err = m.transcodeResponse(respAction, respData)
+		}
+		return
+	}
+	respAction, respData, err = m.remoteHandler.Call(rqId, s, m.gateway.String(), format.String(), c, actionId, actionData)
 	return
 }
 
+// transcodeResponse 将handler的proto响应转换为json, 响应action未注册描述时返回错误, 客户端无法解析proto数据
+func (m *Manager) transcodeResponse(respAction codec.Action, respData []byte) ([]byte, error) {
+	if respAction.Id <= 0 {
+		return respData, nil
+	}
+	if !m.transcoder.Transcodable(respAction.Id) {
+		return nil, errors.New("action manager error: transcode response failed, err=response action " + respAction.String() + " not registered")
+	}
+	data, err := m.transcoder.ToJson(respAction.Id, respData)
+	if err != nil {
+		return nil, errors.New("action manager error: transcode response failed, err=" + err.Error())
+	}
+	return data, nil
+}
+
+// Transcoder 返回数据转换, 未设置时为nil
+func (m *Manager) Transcoder() Transcoder {
+	return m.transcoder
+}
+
 func (m *Manager) Authenticate(c socket.Conn, rqId string, b codec.DataBuilder, tp, id string, secret string) (auth *socket.Authentication, key []byte, err error) {
 	rq := &handlerv1.AuthenticateRequest{
 		Gateway: m.gateway.String(),
@@ -1,6 +1,7 @@
 package action
 
 import (
+	"errors"
 	"github.com/obnahsgnaw/socketutil/codec"
 	"testing"
 )
@@ -16,3 +17,38 @@ func TestManager(t *testing.T) {
 
 	println(s)
 }
+
+type jsonTranscoder struct{}
+
+func (jsonTranscoder) Transcodable(id codec.ActionId) bool { return id == 2 }
+func (jsonTranscoder) ToProto(_ codec.ActionId, data []byte) ([]byte, error) {
+	return data, nil
+}
+func (jsonTranscoder) ToJson(_ codec.ActionId, data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty")
+	}
+	return append([]byte("json:"), data...), nil
+}
+
+func TestTranscodeResponse(t *testing.T) {
+	m := NewManager(Transcoding(jsonTranscoder{}))
+	cases := []struct {
+		name    string
+		action  codec.Action
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{"registered", codec.NewAction(2, "b"), "pb", "json:pb", false},
+		{"not registered", codec.NewAction(3, "c"), "pb", "", true},
+		{"no response", codec.Action{}, "", "", false},
+		{"transcode failed", codec.NewAction(2, "b"), "", "", true},
+	}
+	for _, c := range cases {
+		data, err := m.transcodeResponse(c.action, []byte(c.data))
+		if (err != nil) != c.wantErr || string(data) != c.want {
+			t.Errorf("%s: got %q, %v", c.name, data, err)
+		}
+	}
+}
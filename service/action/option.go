@@ -22,3 +22,22 @@ func Gateway(host url.Host) Option {
 		s.gateway = host
 	}
 }
+
+func ActionRule(id codec.ActionId, rule Rule) Option {
+	return func(s *Manager) {
+		s.SetActionRule(id, rule)
+	}
+}
+
+func PatternRule(pattern string, rule Rule) Option {
+	return func(s *Manager) {
+		s.SetPatternRule(pattern, rule)
+	}
+}
+
+// Transcoding 设置proto与json数据的转换
+func Transcoding(t Transcoder) Option {
+	return func(s *Manager) {
+		s.transcoder = t
+	}
+}
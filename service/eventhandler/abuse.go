@@ -0,0 +1,55 @@
+package eventhandler
+
+import (
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/abuse"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
+	"go.uber.org/zap/zapcore"
+	"strconv"
+)
+
+func (e *Event) AbuseDetector() *abuse.Detector {
+	return e.abuse
+}
+
+// abuseIntercept 拒绝被封禁ip的连接
+func (e *Event) abuseIntercept(c socket.Conn) error {
+	if ip := socket.ConnIp(c); ip != "" && e.abuse.Banned(ip) {
+		return errors.New("ip " + ip + " banned")
+	}
+	return nil
+}
+
+// reportAbuse 上报异常流量, 超过阈值时关闭连接, ip被封禁时同时关闭该ip的其他连接
+func (e *Event) reportAbuse(c socket.Conn, rqId string, kind abuse.Kind) {
+	if e.abuse == nil {
+		return
+	}
+	ip := socket.ConnIp(c)
+	r := e.abuse.Report(c.Fd(), ip, kind)
+	if !r.Close {
+		return
+	}
+	if !r.Banned {
+		e.log(c, rqId, "abuse detected, kind="+kind.String()+", score="+strconv.Itoa(r.Score), zapcore.WarnLevel)
+		e.Disconnect(c, gatewayv1.DisconnectNotice_Abuse, "close by abuse: "+kind.String())
+		return
+	}
+	e.log(c, rqId, "abuse detected, ip "+ip+" banned, kind="+kind.String()+", ip score="+strconv.Itoa(r.IpScore), zapcore.WarnLevel)
+	e.Disconnect(c, gatewayv1.DisconnectNotice_Abuse, "close by abuse: ip banned")
+	e.CloseBanned(ip)
+}
+
+// CloseBanned 关闭被封禁ip的所有连接
+func (e *Event) CloseBanned(ip string) {
+	if e.ss == nil || ip == "" {
+		return
+	}
+	e.ss.RangeConnections(func(conn socket.Conn) bool {
+		if socket.ConnIp(conn) == ip {
+			e.Disconnect(conn, gatewayv1.DisconnectNotice_Abuse, "close by abuse: ip banned")
+		}
+		return true
+	})
+}
@@ -17,6 +17,18 @@ func GetHeartbeatInterval(c socket.Conn) int64 {
 	return 0
 }
 
+// SetMissedProbes 设置连续未响应的心跳探测次数
+func SetMissedProbes(c socket.Conn, n int) {
+	c.Context().SetOptional("missed_probes", n)
+}
+
+func MissedProbes(c socket.Conn) int {
+	if v, ok := c.Context().GetOptional("missed_probes"); ok {
+		return v.(int)
+	}
+	return 0
+}
+
 func SetCloseReason(c socket.Conn, reason string) {
 	c.Context().SetOptional("close_reason", reason)
 }
@@ -84,11 +96,26 @@ func ClearCryptoKey(c socket.Conn) {
 }
 
 func WithTempPackager(c socket.Conn, pkg []byte, f func(pkg []byte) ([]byte, error)) (err error) {
+	return WithLimitedTempPackager(c, pkg, socket.SizeLimit{}, f)
+}
+
+// WithLimitedTempPackager 缓存的数据超过limit.MaxBuffer时返回socket.ErrBufferTooLarge, 且不再缓存
+// 拆包后剩余的未完整帧超过limit.MaxFrame时返回socket.ErrFrameTooLarge
+func WithLimitedTempPackager(c socket.Conn, pkg []byte, limit socket.SizeLimit, f func(pkg []byte) ([]byte, error)) (err error) {
 	if v, ok := c.Context().GetAndDelOptional("pkgTmp"); ok {
+		if err = limit.CheckBuffer(len(v.([]byte)) + len(pkg)); err != nil {
+			return
+		}
 		pkg = append(v.([]byte), pkg...)
 	}
 	var temp []byte
 	if temp, err = f(pkg); err == nil {
+		if err = limit.CheckFrame(len(temp)); err != nil {
+			return
+		}
+		if err = limit.CheckBuffer(len(temp)); err != nil {
+			return
+		}
 		c.Context().SetOptional("pkgTmp", temp)
 	}
 
@@ -0,0 +1,73 @@
+package connutil
+
+import (
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/engine/moc"
+	"testing"
+)
+
+// keepAll 模拟只有头部、包体未到齐的长帧, 所有数据都作为剩余缓存
+func keepAll(pkg []byte) ([]byte, error) {
+	return pkg, nil
+}
+
+func newConn() socket.Conn {
+	return moc.NewConn("1", moc.NewAddr("tcp", "127.0.0.1:1"), moc.NewAddr("tcp", "127.0.0.1:2"))
+}
+
+func TestWithLimitedTempPackagerFrame(t *testing.T) {
+	c := newConn()
+	limit := socket.SizeLimit{MaxFrame: 8}
+	if err := WithLimitedTempPackager(c, make([]byte, 5), limit, keepAll); err != nil {
+		t.Fatal(err)
+	}
+	if err := WithLimitedTempPackager(c, make([]byte, 5), limit, keepAll); !errors.Is(err, socket.ErrFrameTooLarge) {
+		t.Fatalf("expected ErrFrameTooLarge, got %v", err)
+	}
+	if _, ok := c.Context().GetOptional("pkgTmp"); ok {
+		t.Error("oversize frame should not be cached")
+	}
+}
+
+func TestWithLimitedTempPackagerBuffer(t *testing.T) {
+	c := newConn()
+	limit := socket.SizeLimit{MaxBuffer: 8}
+	if err := WithLimitedTempPackager(c, make([]byte, 5), limit, keepAll); err != nil {
+		t.Fatal(err)
+	}
+	called := false
+	err := WithLimitedTempPackager(c, make([]byte, 5), limit, func(pkg []byte) ([]byte, error) {
+		called = true
+		return nil, nil
+	})
+	if !errors.Is(err, socket.ErrBufferTooLarge) {
+		t.Fatalf("expected ErrBufferTooLarge, got %v", err)
+	}
+	if called {
+		t.Error("oversize buffer should not be decoded")
+	}
+}
+
+func TestWithLimitedTempPackagerComplete(t *testing.T) {
+	c := newConn()
+	limit := socket.SizeLimit{MaxFrame: 8, MaxBuffer: 8}
+	// 完整的帧拆完后没有剩余, 不受长度限制
+	var got int
+	err := WithLimitedTempPackager(c, make([]byte, 32), socket.SizeLimit{MaxFrame: 8}, func(pkg []byte) ([]byte, error) {
+		got = len(pkg)
+		return nil, nil
+	})
+	if err != nil || got != 32 {
+		t.Fatalf("got %d, %v", got, err)
+	}
+	if err = WithLimitedTempPackager(c, make([]byte, 4), limit, keepAll); err != nil {
+		t.Fatal(err)
+	}
+	if err = WithLimitedTempPackager(c, make([]byte, 4), limit, func(pkg []byte) ([]byte, error) {
+		got = len(pkg)
+		return nil, nil
+	}); err != nil || got != 8 {
+		t.Fatalf("got %d, %v", got, err)
+	}
+}
@@ -0,0 +1,66 @@
+package eventhandler
+
+import (
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/service/action"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
+	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
+)
+
+// Disconnect 记录关闭原因, 通知客户端后关闭连接
+// 完成编码协商的连接先收到Disconnect, websocket连接以close帧携带状态码和原因, http连接在下次响应的X-Disconnect头中告知
+// mqtt连接在disconnect的action主题上告知, 原始协议的mqtt设备无法得知原因
+func (e *Event) Disconnect(c socket.Conn, reason gatewayv1.DisconnectNotice_Reason, closeReason string) {
+	e.notifyDisconnect(c, &gatewayv1.DisconnectNotice{
+		Reason:  reason,
+		Message: reason.String(),
+	})
+	e.closeWithReason(c, reason, closeReason)
+}
+
+// closeWithReason 记录关闭原因并携带原因关闭, 不发送Disconnect
+func (e *Event) closeWithReason(c socket.Conn, reason gatewayv1.DisconnectNotice_Reason, closeReason string) {
+	connutil.SetCloseReason(c, closeReason)
+	socket.CloseWithReason(c, CloseCode(reason), reason.String())
+}
+
+// Drain 排空服务: 拒绝新连接, 通知已有连接网关下线及建议重连的地址, 等待处理中的消息完成后分批关闭剩余连接
+func (e *Event) Drain(ctx context.Context, reconnect string, c socket.DrainConfig) error {
+	if e.ss == nil {
+		return errors.New("server not booted")
+	}
+	reason := gatewayv1.DisconnectNotice_ServerShutdown
+	c.Notify = func(conn socket.Conn) {
+		e.notifyDisconnect(conn, &gatewayv1.DisconnectNotice{
+			Reason:    reason,
+			Message:   reason.String(),
+			Reconnect: reconnect,
+		})
+	}
+	c.Close = func(conn socket.Conn) {
+		e.closeWithReason(conn, reason, "close by drain")
+	}
+	return e.ss.Drain(ctx, c)
+}
+
+// notifyDisconnect 完成编码协商的连接才能收到通知
+func (e *Event) notifyDisconnect(c socket.Conn, notice *gatewayv1.DisconnectNotice) {
+	if connutil.CoderInitialized(c) {
+		_ = e.SendAction(c, action.New(gatewayv1.ActionId_Disconnect), notice)
+	}
+}
+
+// CloseCode 断开原因对应的websocket关闭状态码, 没有标准状态码的为4000+原因
+func CloseCode(reason gatewayv1.DisconnectNotice_Reason) int {
+	switch reason {
+	case gatewayv1.DisconnectNotice_ServerShutdown:
+		return 1001
+	case gatewayv1.DisconnectNotice_Rejected, gatewayv1.DisconnectNotice_Abuse:
+		return 1008
+	case gatewayv1.DisconnectNotice_PayloadTooLarge:
+		return 1009
+	}
+	return 4000 + int(reason)
+}
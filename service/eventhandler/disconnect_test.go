@@ -0,0 +1,50 @@
+package eventhandler
+
+import (
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
+	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
+	"testing"
+)
+
+type reasonConn struct {
+	multicastConn
+	code   int
+	reason string
+}
+
+func (c *reasonConn) CloseWithReason(code int, reason string) {
+	c.code, c.reason = code, reason
+}
+
+func TestDisconnect(t *testing.T) {
+	e, conns := newMulticast(1)
+	c := &reasonConn{multicastConn: multicastConn{fd: 1, ctx: conns[0].Context()}}
+	e.Disconnect(c, gatewayv1.DisconnectNotice_HeartbeatTimeout, "close by heartbeat checker")
+	if len(c.out) != 1 {
+		t.Error("need notify client before close")
+		return
+	}
+	if c.code != 4001 || c.reason != gatewayv1.DisconnectNotice_HeartbeatTimeout.String() {
+		t.Errorf("need close with reason, got code=%d reason=%s", c.code, c.reason)
+		return
+	}
+	if connutil.GetCloseReason(c) != "close by heartbeat checker" {
+		t.Error("need record close reason")
+	}
+	if CloseCode(gatewayv1.DisconnectNotice_ServerShutdown) != 1001 {
+		t.Error("server shutdown need going away code")
+	}
+}
+
+func TestKickNotifiesOnce(t *testing.T) {
+	e, conns := newMulticast(1)
+	c := &reasonConn{multicastConn: multicastConn{fd: 1, ctx: conns[0].Context()}}
+	e.Kick(c, kickedReason)
+	if len(c.out) != 1 {
+		t.Errorf("need exactly one notice on kick, got %d", len(c.out))
+		return
+	}
+	if c.code != CloseCode(gatewayv1.DisconnectNotice_Kicked) {
+		t.Errorf("need close with kicked code, got %d", c.code)
+	}
+}
@@ -12,11 +12,13 @@ import (
 	"github.com/obnahsgnaw/goutils/security/coder"
 	"github.com/obnahsgnaw/goutils/security/esutil"
 	"github.com/obnahsgnaw/goutils/security/rsautil"
-	bindv1 "github.com/obnahsgnaw/socketapi/gen/bind/v1"
 	handlerv1 "github.com/obnahsgnaw/socketapi/gen/handler/v1"
+	"github.com/obnahsgnaw/socketgateway/pkg/abuse"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket/limiter"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"github.com/obnahsgnaw/socketgateway/pkg/timewheel"
 	"github.com/obnahsgnaw/socketgateway/service/action"
 	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
 	"github.com/obnahsgnaw/socketgateway/service/manage"
@@ -24,39 +26,47 @@ import (
 	"github.com/obnahsgnaw/socketutil/codec"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"net"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // Event 事件处理引擎
 type Event struct {
-	ctx              context.Context
-	am               *action.Manager
-	tickHandlers     map[string]TickHandler
-	logWatcher       func(socket.Conn, string, zapcore.Level, ...zap.Field)
-	logger           *zap.Logger
-	st               sockettype.SocketType
-	codecProvider    codec.Provider
-	codedProvider    codec.DataBuilderProvider
-	authEnable       bool
-	tickInterval     time.Duration
-	commonPrivateKey []byte
-	defaultUser      *socket.AuthUser
-	rsa              *rsautil.Rsa // 连接后第一包发送rsa加密 aes密钥@时间戳 交换密钥， 服务端 rsa解密得到aes 密钥， 后续使用其来解析aes cbc 加密的内容体， 内容体组成为：iv（16byte）+内容 (aes256 最小16字节) 一个内容最小32字节
-	es               *esutil.ADes
-	esTp             esutil.EsType
-	esMode           esutil.EsMode
-	secEncoder       coder.Encoder
-	secEncode        bool
-	secTtl           int64 // second
-	ss               *socket.Server
-	defDataType      codec.Name
-
-	openInterceptor     func() error
+	ctx               context.Context
+	am                *action.Manager
+	tickers           []ticker
+	wheel             *timewheel.Wheel
+	wheelOnce         sync.Once
+	tickWorkers       int
+	heartbeatInterval time.Duration
+	logWatcher        func(socket.Conn, string, zapcore.Level, ...zap.Field)
+	logger            *zap.Logger
+	st                sockettype.SocketType
+	codecProvider     codec.Provider
+	codedProvider     codec.DataBuilderProvider
+	authEnable        bool
+	tickInterval      time.Duration
+	commonPrivateKey  []byte
+	defaultUser       *socket.AuthUser
+	rsa               *rsautil.Rsa // 连接后第一包发送rsa加密 aes密钥@时间戳 交换密钥， 服务端 rsa解密得到aes 密钥， 后续使用其来解析aes cbc 加密的内容体， 内容体组成为：iv（16byte）+内容 (aes256 最小16字节) 一个内容最小32字节
+	es                *esutil.ADes
+	esTp              esutil.EsType
+	esMode            esutil.EsMode
+	secEncoder        coder.Encoder
+	secEncode         bool
+	secTtl            int64 // second
+	ss                *socket.Server
+	defDataType       codec.Name
+
+	openInterceptors    []OpenFunc
+	ipInterceptor       OpenFunc
+	proxyProtocol       bool
+	abuse               *abuse.Detector
 	receiveInterceptors []HandleFunc
 	sendInterceptors    []HandleFunc
 
@@ -77,6 +87,11 @@ type Event struct {
 	trigger *manage.Trigger
 
 	authenticateLimiter *limiter.TimeLimiter
+
+	loginPolicies  map[string]LoginPolicy
+	uidLoginPolicy LoginPolicy
+	peerLocator    func(id socket.ConnId) []string
+	rawRouter      func(ctx context.Context, rqId, target string, data []byte) error
 }
 
 type LogWatcher func(c socket.Conn, msg string, l zapcore.Level, data ...zap.Field)
@@ -96,7 +111,7 @@ func New(ctx context.Context, m *action.Manager, st sockettype.SocketType, optio
 		ctx:                 ctx,
 		am:                  m,
 		st:                  st,
-		tickHandlers:        make(map[string]TickHandler),
+		loginPolicies:       make(map[string]LoginPolicy),
 		rsa:                 rsautil.New(rsautil.PKCS1Public(), rsautil.PKCS1Private(), rsautil.SignHash(crypto.SHA256), rsautil.Encoder(coder.B64StdEncoding)),
 		es:                  esutil.New(esutil.Aes256, esutil.CbcMode, esutil.Encoder(coder.B64StdEncoding)),
 		esTp:                esutil.Aes256,
@@ -132,7 +147,7 @@ func New(ctx context.Context, m *action.Manager, st sockettype.SocketType, optio
 	}
 	s.codedProvider = codec.NewDbp()
 	s.With(options...)
-	s.AddTicker("authenticate-ticker", authenticateTicker(time.Second*10))
+	s.addTicker(s.authenticateTicker(time.Second * 10))
 	if s.st.IsWss() && !s.withoutWssDftUserAuthenticate {
 		s.withUserAuthenticate()
 	}
@@ -156,7 +171,17 @@ func (e *Event) OnOpen(_ *socket.Server, c socket.Conn) {
 		e.serverErrorLog("on open panic, err=", err, ", stack=", stack)
 	})
 	e.log(c, "", "connected", zapcore.InfoLevel)
+	if err := e.ss.Admit(c); err != nil {
+		e.log(c, "", "connection rejected, err="+err.Error(), zapcore.WarnLevel)
+		reason := gatewayv1.DisconnectNotice_Rejected
+		if errors.Is(err, socket.ErrDraining) {
+			reason = gatewayv1.DisconnectNotice_ServerShutdown
+		}
+		e.Disconnect(c, reason, "close by admission: "+err.Error())
+		return
+	}
 	e.triggerConnectionJoin(c)
+	e.startTimers(c)
 	e.openIntercept(c)
 }
 
@@ -173,8 +198,12 @@ func (e *Event) handleClose(_ *socket.Server, c socket.Conn, err error) {
 	}()
 	reason := e.getCloseReason(c, err)
 	e.log(c, "", "disconnected "+reason, zapcore.InfoLevel)
+	e.stopTimers(c)
 	e.am.HandleClose(c)
 	e.ss.ClearAuthentication(c)
+	if e.abuse != nil {
+		e.abuse.Forget(c.Fd())
+	}
 }
 
 func (e *Event) OnTraffic(_ *socket.Server, c socket.Conn) {
@@ -190,14 +219,16 @@ func (e *Event) OnTraffic(_ *socket.Server, c socket.Conn) {
 	if rawPkg, err = c.Read(); err != nil {
 		e.log(c, rqId, "packaged read failed, err="+err.Error(), zapcore.WarnLevel)
 		e.triggerConnectionMessage(c, "error", "read message failed, err="+err.Error(), nil)
+		e.sizeLimitClose(c, rqId, err)
 		return
 	}
 	// Initialize the encryption and decryption key
 	keyHit, secResponse, initPackage, secErr := e.authenticate(c, rqId, rawPkg)
 	if keyHit {
-		_ = c.Write([]byte(secResponse))
+		_ = socket.WriteWithPriority(c, []byte(secResponse), socket.PriorityControl)
 		if secErr != nil {
 			e.log(c, rqId, "authenticate failed, err="+secErr.Error(), zapcore.WarnLevel)
+			e.reportAbuse(c, rqId, abuse.HandshakeErr)
 		} else {
 			e.log(c, rqId, "authenticate success", zapcore.DebugLevel)
 		}
@@ -222,7 +253,7 @@ func (e *Event) OnTraffic(_ *socket.Server, c socket.Conn) {
 			e.log(c, rqId, "action handled data", zapcore.DebugLevel, zap.String("rq_action", rqAction.String()), zap.String("resp_action", respAction.String()), zap.ByteString("rq_data", rqData), zap.ByteString("resp_data", respData))
 		}
 		if len(respPackage) > 0 {
-			if err1 = e.write(c, respPackage); err1 != nil {
+			if err1 = e.writeAction(c, respAction, respPackage); err1 != nil {
 				e.log(c, rqId, err1.Error(), zapcore.ErrorLevel)
 			}
 		} else {
@@ -231,28 +262,19 @@ func (e *Event) OnTraffic(_ *socket.Server, c socket.Conn) {
 	})
 	if err != nil {
 		e.log(c, rqId, "package codec decode failed, err="+err.Error(), zapcore.WarnLevel)
+		if e.sizeLimitClose(c, rqId, err) {
+			return
+		}
 		e.log(c, rqId, "package codec decode data", zapcore.DebugLevel, zap.ByteString("package", initPackage))
 		if err = e.gatewayErrorResponse(c, rqId, gatewayv1.GatewayError_PackageErr, 0); err != nil {
 			e.log(c, rqId, err.Error(), zapcore.ErrorLevel)
 		}
+		e.reportAbuse(c, rqId, abuse.PackageErr)
 	}
 }
 
-func (e *Event) OnTick(s *socket.Server) (delay time.Duration) {
-	defer utils.RecoverHandler("on tick", func(err, stack string) {
-		if e.logger != nil {
-			e.logger.Error("on tick panic, err=" + err + ", stack=" + stack)
-		}
-	})
-	s.RangeConnections(func(c socket.Conn) bool {
-		for _, h := range e.tickHandlers {
-			if !h(s, c) {
-				break
-			}
-		}
-		return true
-	})
-
+// OnTick 连接的定时任务由时间轮调度, 不再在tick中遍历连接
+func (e *Event) OnTick(_ *socket.Server) (delay time.Duration) {
 	return e.tickInterval
 }
 
@@ -270,6 +292,11 @@ func (e *Event) handleRaw(c socket.Conn, rqId string, packedPkg []byte) bool {
 			e.log(c, rqId, "decrypt data failed, err="+decErr.Error(), zapcore.WarnLevel)
 			return true
 		}
+		if err := e.payloadCheck(decryptedData); err != nil {
+			e.log(c, rqId, "raw data rejected, err="+err.Error(), zapcore.WarnLevel)
+			e.sizeLimitClose(c, rqId, err)
+			return true
+		}
 		if respData, subActions, dispatchErr := e.am.Raw(c, rqId, e.internalDataCoder, c.Context().Authentication().Protocol, decryptedData, 0); dispatchErr != nil {
 			e.log(c, rqId, "package raw dispatch failed,err="+dispatchErr.Error(), zapcore.ErrorLevel)
 		} else {
@@ -301,6 +328,9 @@ func (e *Event) handleRaw(c socket.Conn, rqId string, packedPkg []byte) bool {
 						}
 					}
 					if subConn == nil {
+						if subAction.ActionId <= 0 && e.routeRaw(c, rqId, subAction.Target, subAction.Data) {
+							continue
+						}
 						e.log(c, rqId, "sub action no conn for target="+subAction.Target, zapcore.WarnLevel)
 						continue
 					}
@@ -357,6 +387,9 @@ func (e *Event) handleRaw(c socket.Conn, rqId string, packedPkg []byte) bool {
 												}
 											}
 											if subConn1 == nil {
+												if e.routeRaw(c, rqId, subAction1.Target, subAction1.Data) {
+													continue
+												}
 												e.log(c, rqId, "sub action no conn for target="+subAction1.Target, zapcore.WarnLevel)
 												continue
 											}
@@ -383,6 +416,20 @@ func (e *Event) handleRaw(c socket.Conn, rqId string, packedPkg []byte) bool {
 	return false
 }
 
+// routeRaw 子动作的目标不在当前网关时, 将原始数据转发到持有目标的网关
+func (e *Event) routeRaw(c socket.Conn, rqId, target string, data []byte) bool {
+	if e.rawRouter == nil || len(data) == 0 {
+		return false
+	}
+	// 携带来源连接的公司id, 开启租户隔离的网关据此校验目标
+	if err := e.rawRouter(tenant.WithCid(e.ctx, tenant.ConnCid(c)), rqId, target, data); err != nil {
+		e.log(c, rqId, "sub action route to gateway failed, target="+target+", err="+err.Error(), zapcore.WarnLevel)
+		return false
+	}
+	e.log(c, rqId, "sub action routed to gateway, target="+target, zapcore.InfoLevel)
+	return true
+}
+
 // HandleMessage handle message
 func (e *Event) handleMessage(c socket.Conn, rqId string, packedPkg []byte) (rqAction, respAction codec.Action, rqData, respData, respPackage []byte, err error) {
 	var err1 error
@@ -397,6 +444,11 @@ func (e *Event) handleMessage(c socket.Conn, rqId string, packedPkg []byte) (rqA
 		if respPackage, err1 = e.packGatewayError(c, gatewayv1.GatewayError_DecryptErr, 0); err1 != nil {
 			err = errors.New(err.Error() + ":" + err1.Error())
 		}
+		e.reportAbuse(c, rqId, abuse.DecryptErr)
+		return
+	}
+	if err = e.payloadCheck(decryptedData); err != nil {
+		e.sizeLimitClose(c, rqId, err)
 		return
 	}
 
@@ -404,6 +456,7 @@ func (e *Event) handleMessage(c socket.Conn, rqId string, packedPkg []byte) (rqA
 	gwPkg, acErr := e.actionDecode(c, decryptedData)
 	if acErr != nil {
 		err = errors.New("action decode failed, err=" + acErr.Error())
+		e.reportAbuse(c, rqId, abuse.PackageErr)
 		return
 	}
 	rqData = gwPkg.Data
@@ -415,11 +468,12 @@ func (e *Event) handleMessage(c socket.Conn, rqId string, packedPkg []byte) (rqA
 		if respPackage, err1 = e.packGatewayError(c, gatewayv1.GatewayError_NoActionHandler, gwPkg.Action.Val()); err1 != nil {
 			err = errors.New(err.Error() + ":" + err1.Error())
 		}
+		e.reportAbuse(c, rqId, abuse.UnknownAction)
 		return
 	}
 
 	// Authentication checks, excluding certification-related actions
-	if !e.authCheck(c, gatewayv1.ActionId(gwPkg.Action)) {
+	if !e.authCheck(c, rqAction) {
 		err = errors.New("handle failed, no auth")
 		if respPackage, err1 = e.packGatewayError(c, gatewayv1.GatewayError_NoAuth, gwPkg.Action.Val()); err1 != nil {
 			err = errors.New(err.Error() + ":" + err1.Error())
@@ -488,10 +542,6 @@ func (e *Event) WatchLog(watcher LogWatcher) {
 	e.logWatcher = watcher
 }
 
-func (e *Event) AddTicker(name string, ticker TickHandler) {
-	e.tickHandlers[name] = ticker
-}
-
 func (e *Event) initCodec(c socket.Conn, rqId string, name codec.Name) {
 	dataCoderName, protoCoder, gatewayPkgCoder := e.codecProvider.GetByName(name)
 	dataCoder := e.codedProvider.Provider(dataCoderName)
@@ -513,6 +563,9 @@ func (e *Event) authenticate(c socket.Conn, rqId string, pkg []byte) (hit bool,
 	})
 	if !e.CryptoKeyInitialized(c) {
 		// proxy
+		if !e.resolveProxy(c, rqId, pkg) {
+			return
+		}
 		if pkg = e.initProxy(pkg); len(pkg) == 0 {
 			return
 		}
@@ -652,24 +705,15 @@ func (e *Event) authenticate(c socket.Conn, rqId string, pkg []byte) (hit bool,
 			user = e.defaultUser
 		}
 
-		if authentication.Type != "user" {
-			// 验证是否已（当前、其他）
-			cc := e.ss.GetAuthenticatedConn(authentication.Id)
-			for _, ccc := range cc {
-				e.log(c, rqId, utils.ToStr("authenticate closed exist client:"+strconv.Itoa(ccc.Fd())), zapcore.DebugLevel)
-				ccc.Close()
-			}
-			for _, gw := range e.ss.GwManager().Get("gateway") {
-				err = e.ss.GwManager().HostCall(e.ctx, gw, 0, "gateway", "gateway", rqId, "", "", func(ctx context.Context, cc *grpc.ClientConn) error {
-					_, err1 := bindv1.NewBindServiceClient(cc).DisconnectTarget(ctx, &bindv1.DisconnectTargetRequest{
-						Id: authentication.Id,
-					})
-					return err1
-				})
-				if err != nil {
-					response = "222"
-					return
-				}
+		// 按登录策略处理同一身份的已有会话（当前、其他网关）
+		if err = e.LoginCheck(c, rqId, SessionKey{Type: authentication.Type, Id: authentication.Id}); err != nil {
+			response = "222"
+			return
+		}
+		if user != nil && e.AuthEnabled() {
+			if err = e.LoginCheck(c, rqId, SessionKey{Id: strconv.Itoa(int(user.Id)), Uid: true}); err != nil {
+				response = "222"
+				return
 			}
 		}
 
@@ -727,6 +771,63 @@ func (e *Event) initProxy(pkg []byte) []byte {
 	return pkg
 }
 
+// resolveProxy 配置了ProxyProtocol时从连接的首包解析真实ip并重新执行ip检查, 返回false时连接已关闭
+// 未配置时不信任代理头, 避免客户端伪造来源ip
+func (e *Event) resolveProxy(c socket.Conn, rqId string, pkg []byte) bool {
+	if !e.proxyProtocol {
+		return true
+	}
+	if _, ok := c.Context().GetOptional("proxyResolved"); ok {
+		return true
+	}
+	c.Context().SetOptional("proxyResolved", true)
+	ip := proxyRealIp(pkg)
+	if ip == "" {
+		// 前置代理时真实ip未知的连接不放行
+		e.log(c, rqId, "connection rejected, proxy protocol header required", zapcore.WarnLevel)
+		e.Disconnect(c, gatewayv1.DisconnectNotice_Rejected, "close by proxy protocol: header required")
+		return false
+	}
+	if err := e.ss.SetRealIp(c, ip); err != nil {
+		e.log(c, rqId, "connection rejected, real ip="+ip+", err="+err.Error(), zapcore.WarnLevel)
+		e.Disconnect(c, gatewayv1.DisconnectNotice_Rejected, "close by admission: "+err.Error())
+		return false
+	}
+	// 拿到真实ip后重新执行ip及封禁检查
+	return e.realIpIntercept(c)
+}
+
+// proxyRealIp 解析proxy protocol头中的源ip
+func proxyRealIp(pkg []byte) string {
+	if bytes.HasPrefix(pkg, []byte("PROXY")) {
+		line := pkg
+		if i := bytes.Index(pkg, []byte("\r\n")); i >= 0 {
+			line = pkg[:i]
+		}
+		fields := bytes.Fields(line)
+		if len(fields) >= 3 {
+			if ip := net.ParseIP(string(fields[2])); ip != nil {
+				return ip.String()
+			}
+		}
+		return ""
+	}
+	v2 := []byte{0x0d, 0x0a, 0x0d, 0x0a, 0x00, 0x0d, 0x0a, 0x51, 0x55, 0x49, 0x54, 0x0a}
+	if bytes.HasPrefix(pkg, v2) && len(pkg) >= 16 {
+		switch pkg[13] >> 4 {
+		case 0x1: // AF_INET
+			if len(pkg) >= 20 {
+				return net.IP(pkg[16:20]).String()
+			}
+		case 0x2: // AF_INET6
+			if len(pkg) >= 32 {
+				return net.IP(pkg[16:32]).String()
+			}
+		}
+	}
+	return ""
+}
+
 func (e *Event) ProtoCoder(c socket.Conn) codec.Codec {
 	return connutil.ProtoCoder(c)
 }
@@ -768,11 +869,35 @@ func (e *Event) CryptoKeyInitialized(c socket.Conn) bool {
 }
 
 func (e *Event) codecDecode(c socket.Conn, pkg []byte, handler func(pkg []byte)) error {
-	return connutil.WithTempPackager(c, pkg, func(kg1 []byte) ([]byte, error) {
-		return e.ProtoCoder(c).Unmarshal(pkg, handler)
+	return connutil.WithLimitedTempPackager(c, pkg, e.sizeLimit(), func(kg1 []byte) ([]byte, error) {
+		return e.ProtoCoder(c).Unmarshal(kg1, handler)
 	})
 }
 
+func (e *Event) sizeLimit() socket.SizeLimit {
+	if e.ss == nil {
+		return socket.SizeLimit{}
+	}
+	return e.ss.SizeLimit()
+}
+
+func (e *Event) payloadCheck(pkg []byte) error {
+	if max := e.sizeLimit().MaxPayload; max > 0 && len(pkg) > max {
+		return socket.ErrPayloadTooLarge
+	}
+	return nil
+}
+
+// sizeLimitClose 超出包大小限制时关闭连接
+func (e *Event) sizeLimitClose(c socket.Conn, rqId string, err error) bool {
+	if !socket.IsSizeLimitErr(err) {
+		return false
+	}
+	e.log(c, rqId, "size limit exceeded, err="+err.Error(), zapcore.WarnLevel)
+	e.Disconnect(c, gatewayv1.DisconnectNotice_PayloadTooLarge, "close by size limit: "+err.Error())
+	return true
+}
+
 func (e *Event) codecEncode(c socket.Conn, pkg []byte) ([]byte, error) {
 	return e.ProtoCoder(c).Marshal(pkg)
 }
@@ -800,12 +925,29 @@ func (e *Event) actionEncode(c socket.Conn, gwPkg *codec.PKG) ([]byte, error) {
 	return e.GatewayPkgCoder(c).Pack(gwPkg)
 }
 
-func (e *Event) authCheck(c socket.Conn, actionId gatewayv1.ActionId) bool {
-	if !e.authEnable {
+// builtinPublic 握手和保活的内置action, 总是放行, 不受配置的规则影响
+func builtinPublic(act codec.Action) bool {
+	switch gatewayv1.ActionId(act.Id) {
+	case gatewayv1.ActionId_AuthReq, gatewayv1.ActionId_AuthRefreshReq, gatewayv1.ActionId_Ping, gatewayv1.ActionId_Pong:
 		return true
 	}
+	return false
+}
 
-	if actionId == gatewayv1.ActionId_AuthReq || actionId == gatewayv1.ActionId_Ping {
+func (e *Event) authCheck(c socket.Conn, act codec.Action) bool {
+	if builtinPublic(act) {
+		return true
+	}
+	if rule, ok := e.am.Rule(act); ok {
+		if rule.Public {
+			return true
+		}
+		if e.authEnable && !c.Context().Authed() {
+			return false
+		}
+		return rule.Allowed(c.Context().Authentication(), c.Context().User())
+	}
+	if !e.authEnable {
 		return true
 	}
 
@@ -889,7 +1031,7 @@ func (e *Event) gatewayErrorResponse(c socket.Conn, rqId string, errorStatus gat
 	if respPackage, err1 := e.packGatewayError(c, errorStatus, triggerActionId); err1 != nil {
 		e.log(c, rqId, "package gateway error failed, err="+err1.Error(), zapcore.ErrorLevel)
 	} else {
-		if err1 = e.write(c, respPackage); err1 != nil {
+		if err1 = e.writePriority(c, respPackage, socket.PriorityControl); err1 != nil {
 			e.log(c, rqId, err1.Error(), zapcore.ErrorLevel)
 		}
 	}
@@ -897,17 +1039,46 @@ func (e *Event) gatewayErrorResponse(c socket.Conn, rqId string, errorStatus gat
 }
 
 func (e *Event) write(c socket.Conn, data []byte) (err error) {
+	return e.writePriority(c, data, socket.PriorityBusiness)
+}
+
+// writeAction 网关层的action为控制消息, 在出站队列中优先写出
+func (e *Event) writeAction(c socket.Conn, a codec.Action, data []byte) (err error) {
+	return e.writePriority(c, data, actionPriority(a))
+}
+
+func (e *Event) writePriority(c socket.Conn, data []byte, p socket.Priority) (err error) {
 	if data, err = e.sendIntercept(c, data); err != nil {
 		e.log(c, "", "write failed, send intercepted err="+err.Error(), zapcore.ErrorLevel)
 		return err
 	}
-	if err = c.Write(data); err != nil {
+	if err = socket.WriteWithPriority(c, data, p); err != nil {
 		err = utils.NewWrappedError("write failed", err)
 	}
 
 	return
 }
 
+func actionPriority(a codec.Action) socket.Priority {
+	if _, ok := gatewayv1.ActionId_name[int32(a.Id)]; ok && a.Id != 0 {
+		return socket.PriorityControl
+	}
+	return socket.PriorityBusiness
+}
+
+// PackError 发送时打包(编码、加密)失败, 区别于写入连接失败
+type PackError struct {
+	Err error
+}
+
+func (e *PackError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PackError) Unwrap() error {
+	return e.Err
+}
+
 func (e *Event) SendRaw(c socket.Conn, data []byte) (err error) {
 	return e.write(c, data)
 }
@@ -917,9 +1088,10 @@ func (e *Event) Send(c socket.Conn, rqId string, a codec.Action, data []byte) (e
 	if data, err = e.pack(c, a, data); err != nil {
 		e.log(c, rqId, "send action pack failed, err="+err.Error(), zapcore.ErrorLevel)
 		e.log(c, rqId, "send action pack failed data", zapcore.DebugLevel, zap.String("action", a.String()), zap.ByteString("package", data))
+		err = &PackError{Err: err}
 		return
 	}
-	if err = e.write(c, data); err != nil {
+	if err = e.writeAction(c, a, data); err != nil {
 		e.log(c, rqId, "send action failed, err="+err.Error(), zapcore.ErrorLevel)
 		e.log(c, rqId, "send action failed data", zapcore.DebugLevel, zap.String("action", a.String()), zap.ByteString("package", data))
 	} else {
@@ -936,7 +1108,7 @@ func (e *Event) SendAction(c socket.Conn, a codec.Action, data codec.DataPtr) (e
 		e.log(c, "", "send action pack failed data", zapcore.ErrorLevel, zap.String("action", a.String()), zap.Any("package", data))
 		return
 	}
-	if err = e.write(c, packData); err != nil {
+	if err = e.writeAction(c, a, packData); err != nil {
 		e.log(c, "", "send action failed, err="+err.Error(), zapcore.ErrorLevel)
 		e.log(c, "", "send action failed data", zapcore.DebugLevel, zap.String("action", a.String()), zap.ByteString("package", packData))
 	} else {
@@ -990,13 +1162,18 @@ func (e *Event) SocketServer() *socket.Server {
 	return e.ss
 }
 
+// triggerConnectionJoin 连接通过准入后触发, 未触发join的连接关闭时也不触发leave
 func (e *Event) triggerConnectionJoin(c socket.Conn) {
+	c.Context().SetOptional("joined", true)
 	if e.trigger != nil {
 		e.trigger.ConnectionJoin(c)
 	}
 }
 
 func (e *Event) triggerConnectionLeave(c socket.Conn) {
+	if _, ok := c.Context().GetAndDelOptional("joined"); !ok {
+		return
+	}
 	if e.trigger != nil {
 		e.trigger.ConnectionLeave(c)
 	}
@@ -2,11 +2,14 @@ package eventhandler
 
 import (
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
-	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
+	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
 )
 
 type HandleFunc func(conn socket.Conn, pkg []byte) ([]byte, error)
 
+// OpenFunc 连接建立时的拦截器, 返回错误则关闭连接
+type OpenFunc func(conn socket.Conn) error
+
 func (e *Event) AppendReceiveInterceptor(i HandleFunc) {
 	if i != nil {
 		e.receiveInterceptors = append(e.receiveInterceptors, i)
@@ -31,6 +34,18 @@ func (e *Event) PrependSendInterceptor(i HandleFunc) {
 	}
 }
 
+func (e *Event) AppendOpenInterceptor(i OpenFunc) {
+	if i != nil {
+		e.openInterceptors = append(e.openInterceptors, i)
+	}
+}
+
+func (e *Event) PrependOpenInterceptor(i OpenFunc) {
+	if i != nil {
+		e.openInterceptors = append([]OpenFunc{i}, e.openInterceptors...)
+	}
+}
+
 func (e *Event) receiveIntercept(conn socket.Conn, pkg []byte) (newPkg []byte, err error) {
 	if len(e.receiveInterceptors) == 0 {
 		newPkg = pkg
@@ -65,12 +80,45 @@ func (e *Event) sendIntercept(conn socket.Conn, pkg []byte) (newPkg []byte, err
 	return
 }
 
-func (e *Event) openIntercept(c socket.Conn) {
-	if e.openInterceptor == nil {
-		return
+// SetIpInterceptor 设置连接的ip检查, 在其他连接拦截器之前执行, 前置代理解析出真实ip后会单独再执行一次
+func (e *Event) SetIpInterceptor(i OpenFunc) {
+	e.ipInterceptor = i
+}
+
+func (e *Event) ipIntercept(c socket.Conn) bool {
+	if e.ipInterceptor == nil {
+		return true
+	}
+	if err := e.ipInterceptor(c); err != nil {
+		e.Disconnect(c, gatewayv1.DisconnectNotice_Rejected, "close by ip filter: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// realIpIntercept ip过滤及封禁检查, 连接建立时及前置代理解析出真实ip后各执行一次
+func (e *Event) realIpIntercept(c socket.Conn) bool {
+	if !e.ipIntercept(c) {
+		return false
 	}
-	if err := e.openInterceptor(); err != nil {
-		connutil.SetCloseReason(c, "close by interceptor: "+err.Error())
-		c.Close()
+	if e.abuse != nil {
+		if err := e.abuseIntercept(c); err != nil {
+			e.Disconnect(c, gatewayv1.DisconnectNotice_Rejected, "close by abuse: "+err.Error())
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Event) openIntercept(c socket.Conn) bool {
+	if !e.realIpIntercept(c) {
+		return false
+	}
+	for _, i := range e.openInterceptors {
+		if err := i(c); err != nil {
+			e.Disconnect(c, gatewayv1.DisconnectNotice_Rejected, "close by interceptor: "+err.Error())
+			return false
+		}
 	}
+	return true
 }
@@ -0,0 +1,92 @@
+package eventhandler
+
+import (
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/service/action"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
+	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
+	"go.uber.org/zap/zapcore"
+	"time"
+)
+
+// 客户端协商心跳间隔的范围
+const (
+	MinHeartbeatInterval = time.Second * 5
+	MaxHeartbeatInterval = time.Minute * 10
+)
+
+// HeartbeatInterval 返回连接生效的心跳间隔, 协商过的优先, 未开启心跳检查时为0
+func (e *Event) HeartbeatInterval(c socket.Conn) time.Duration {
+	if e.heartbeatInterval <= 0 {
+		return 0
+	}
+	if v := connutil.GetHeartbeatInterval(c); v > 0 {
+		return time.Duration(v) * time.Second
+	}
+	return e.heartbeatInterval
+}
+
+// NegotiateHeartbeat 按客户端期望的心跳间隔(秒)设置连接的心跳间隔, 返回生效的间隔
+func (e *Event) NegotiateHeartbeat(c socket.Conn, seconds int64) time.Duration {
+	if e.heartbeatInterval <= 0 || seconds <= 0 {
+		return e.HeartbeatInterval(c)
+	}
+	interval := time.Duration(seconds) * time.Second
+	if interval < MinHeartbeatInterval {
+		interval = MinHeartbeatInterval
+	}
+	if interval > MaxHeartbeatInterval {
+		interval = MaxHeartbeatInterval
+	}
+	connutil.SetHeartbeatInterval(c, int64(interval/time.Second))
+	return interval
+}
+
+// 主动心跳: 连接静默超过心跳间隔时发送探测, 连续maxMissed次探测无响应后踢掉
+// websocket连接发送ping帧, 其他连接发送网关层的Ping, 由客户端回复Pong
+func (e *Event) activeHeartbeatTicker(maxMissed int) ticker {
+	if maxMissed <= 0 {
+		maxMissed = 1
+	}
+	return ticker{
+		name:  "heartbeat-ticker",
+		first: e.HeartbeatInterval,
+		run: func(server *socket.Server, conn socket.Conn) time.Duration {
+			interval := e.HeartbeatInterval(conn)
+			if remain := time.Until(conn.Context().LastActiveAt().Add(interval)); remain > 0 {
+				connutil.SetMissedProbes(conn, 0)
+				return remain
+			}
+			if conn.Context().Probing() {
+				missed := connutil.MissedProbes(conn) + 1
+				connutil.SetMissedProbes(conn, missed)
+				if missed >= maxMissed {
+					e.Disconnect(conn, gatewayv1.DisconnectNotice_HeartbeatTimeout, "close by heartbeat checker, missed probes")
+					return 0
+				}
+			}
+			e.probe(conn)
+			return interval
+		},
+	}
+}
+
+func (e *Event) probe(c socket.Conn) {
+	c.Context().Probed()
+	// 请求响应式的连接(如http)无法主动下发
+	if w, ok := c.(socket.DirectWriter); ok && w.DirectWrite() {
+		return
+	}
+	if p, ok := c.(socket.Pinger); ok {
+		err := p.Ping(nil)
+		if err == nil {
+			return
+		}
+		if !errors.Is(err, socket.ErrPingUnsupported) {
+			e.log(c, "", "heartbeat ping failed, err="+err.Error(), zapcore.WarnLevel)
+			return
+		}
+	}
+	_ = e.SendAction(c, action.New(gatewayv1.ActionId_Ping), &gatewayv1.PingRequest{})
+}
@@ -0,0 +1,86 @@
+package eventhandler
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"github.com/obnahsgnaw/socketgateway/service/action"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
+	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"testing"
+	"time"
+)
+
+type pingConn struct {
+	multicastConn
+	pings  int
+	closed bool
+}
+
+func (c *pingConn) Ping([]byte) error {
+	c.pings++
+	return nil
+}
+
+func (c *pingConn) Close() {
+	c.closed = true
+}
+
+func TestActiveHeartbeat(t *testing.T) {
+	e := New(context.Background(), action.NewManager(), sockettype.WSS, ActiveHeartbeat(time.Minute, 2))
+	c := &pingConn{multicastConn: multicastConn{fd: 1, ctx: socket.NewContext()}}
+	if d := e.NegotiateHeartbeat(c, 1); d != MinHeartbeatInterval {
+		t.Errorf("need clamp to min interval, got %s", d)
+		return
+	}
+	connutil.SetHeartbeatInterval(c, 0)
+	e.heartbeatInterval = 10 * time.Millisecond
+	hb := e.activeHeartbeatTicker(2)
+	quiet := func() {
+		time.Sleep(15 * time.Millisecond)
+	}
+
+	if d := hb.run(nil, c); d <= 0 || c.pings != 0 {
+		t.Error("active connection need not be probed")
+		return
+	}
+	quiet()
+	if d := hb.run(nil, c); d != e.heartbeatInterval || c.pings != 1 {
+		t.Error("quiet connection need be probed")
+		return
+	}
+	c.ctx.Pong()
+	if c.ctx.Rtt() <= 0 || c.ctx.Probing() {
+		t.Error("pong need track rtt")
+		return
+	}
+	quiet()
+	hb.run(nil, c)
+	quiet()
+	hb.run(nil, c)
+	if c.closed || connutil.MissedProbes(c) != 1 {
+		t.Error("need not close before max missed probes")
+		return
+	}
+	quiet()
+	if d := hb.run(nil, c); d != 0 || !c.closed || c.pings != 3 {
+		t.Errorf("need close after max missed probes, pings=%d", c.pings)
+	}
+}
+
+func TestBuiltinActionsExempt(t *testing.T) {
+	am := action.NewManager()
+	am.SetPatternRule("*", action.Rule{Types: []string{"admin"}})
+	e := New(context.Background(), am, sockettype.TCP)
+	e.authEnable = true
+	c := &multicastConn{fd: 1, ctx: socket.NewContext()}
+	for _, id := range []gatewayv1.ActionId{gatewayv1.ActionId_AuthReq, gatewayv1.ActionId_AuthRefreshReq, gatewayv1.ActionId_Ping, gatewayv1.ActionId_Pong} {
+		if !e.authCheck(c, codec.NewAction(codec.ActionId(id), id.String())) {
+			t.Errorf("built-in action %s need not be overridden by rules", id)
+		}
+	}
+	if e.authCheck(c, codec.NewAction(codec.ActionId(9999), "user.info")) {
+		t.Error("rule need apply to other actions")
+	}
+}
@@ -0,0 +1,216 @@
+package eventhandler
+
+import (
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/service/action"
+	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"sort"
+	"strconv"
+)
+
+// LoginPolicy 同一身份的多端登录策略, 统计范围为所有网关
+type LoginPolicy struct {
+	MaxSessions int  // 同一身份允许的最大会话数, 0为不限制
+	RejectNew   bool // 超出时拒绝新会话, 否则踢掉最早的会话
+}
+
+// LoginKickOld 单端登录, 新会话踢掉旧会话
+func LoginKickOld() LoginPolicy {
+	return LoginPolicy{MaxSessions: 1}
+}
+
+// LoginRejectNew 单端登录, 已有会话时拒绝新会话
+func LoginRejectNew() LoginPolicy {
+	return LoginPolicy{MaxSessions: 1, RejectNew: true}
+}
+
+// LoginAllow 最多允许n个会话, 超出时踢掉最早的会话
+func LoginAllow(n int) LoginPolicy {
+	return LoginPolicy{MaxSessions: n}
+}
+
+// SessionKey 会话身份, Uid为true时Id为用户id, 否则为Type类型的认证id
+type SessionKey struct {
+	Type string
+	Id   string
+	Uid  bool
+}
+
+func (k SessionKey) String() string {
+	if k.Uid {
+		return "uid:" + k.Id
+	}
+	return k.Type + ":" + k.Id
+}
+
+var ErrLoginRejected = errors.New("login rejected, max sessions exceeded")
+
+const kickedReason = "logged in elsewhere"
+
+// LoginPolicy 返回身份对应的登录策略, 未配置时非user类型默认单端登录踢掉旧会话, 其他不限制
+func (e *Event) LoginPolicy(key SessionKey) LoginPolicy {
+	if key.Uid {
+		return e.uidLoginPolicy
+	}
+	if p, ok := e.loginPolicies[key.Type]; ok {
+		return p
+	}
+	if key.Type != "user" {
+		return LoginKickOld()
+	}
+	return LoginPolicy{}
+}
+
+// Sessions 返回当前网关上同一身份的会话, 按连接时间从早到晚
+func (e *Event) Sessions(key SessionKey) (list []socket.Conn) {
+	if key.Uid {
+		list = e.ss.GetIdConn(socket.ConnId{Id: key.Id, Type: "UID"})
+	} else {
+		for _, c := range e.ss.GetAuthenticatedConn(key.Id) {
+			if a := c.Context().Authentication(); a != nil && a.Type == key.Type {
+				list = append(list, c)
+			}
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Context().ConnectedAt().Before(list[j].Context().ConnectedAt())
+	})
+	return
+}
+
+// Kick 通知客户端被踢下线的原因, 然后关闭连接, 只发送KickedNotice, 不再发送DisconnectNotice
+func (e *Event) Kick(c socket.Conn, reason string) {
+	_ = e.SendAction(c, action.New(gatewayv1.ActionId_Kicked), &gatewayv1.KickedNotice{
+		Reason: reason,
+	})
+	e.closeWithReason(c, gatewayv1.DisconnectNotice_Kicked, "close by kicked: "+reason)
+}
+
+// KickSessions 踢掉当前网关上同一身份最早的num个会话, num<=0时全部, 返回踢掉的数量
+func (e *Event) KickSessions(key SessionKey, num int, reason string) int {
+	return e.kickSessions(e.Sessions(key), num, reason)
+}
+
+func (e *Event) kickSessions(list []socket.Conn, num int, reason string) int {
+	if num <= 0 || num > len(list) {
+		num = len(list)
+	}
+	for _, c := range list[:num] {
+		e.Kick(c, reason)
+	}
+	return num
+}
+
+// LoginCheck 按登录策略检查同一身份的已有会话, 超出时拒绝当前连接或踢掉最早的会话
+func (e *Event) LoginCheck(c socket.Conn, rqId string, key SessionKey) error {
+	p := e.LoginPolicy(key)
+	if p.MaxSessions <= 0 || key.Id == "" {
+		return nil
+	}
+	var local []socket.Conn
+	for _, cc := range e.Sessions(key) {
+		if cc.Fd() != c.Fd() {
+			local = append(local, cc)
+		}
+	}
+	peers := e.peers(key, !p.RejectNew)
+
+	// 单端登录踢掉旧会话时无需统计
+	if p.MaxSessions == 1 && !p.RejectNew {
+		e.log(c, rqId, "login policy kick all exist sessions of "+key.String(), zapcore.DebugLevel)
+		e.kickSessions(local, 0, kickedReason)
+		for _, gw := range peers {
+			if _, err := e.peerKickSessions(gw, rqId, key, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	total := len(local)
+	counts := make([]int, len(peers))
+	for i, gw := range peers {
+		n, err := e.peerCountSessions(gw, rqId, key)
+		if err != nil {
+			return err
+		}
+		counts[i] = n
+		total += n
+	}
+	if total < p.MaxSessions {
+		return nil
+	}
+	if p.RejectNew {
+		return ErrLoginRejected
+	}
+	need := total - p.MaxSessions + 1
+	e.log(c, rqId, "login policy kick "+strconv.Itoa(need)+" sessions of "+key.String(), zapcore.DebugLevel)
+	need -= e.kickSessions(local, need, kickedReason)
+	for i, gw := range peers {
+		if need <= 0 {
+			break
+		}
+		if counts[i] == 0 {
+			continue
+		}
+		n, err := e.peerKickSessions(gw, rqId, key, need)
+		if err != nil {
+			return err
+		}
+		need -= n
+	}
+	return nil
+}
+
+// peers 返回可能持有该身份会话的其他网关, 有在线索引时只返回索引中的网关
+// 索引是最终一致的, 同步窗口内或同步失败时可能缺失会话, 需要踢人时仍发送到所有网关
+func (e *Event) peers(key SessionKey, kick bool) []string {
+	if e.peerLocator == nil || kick {
+		return e.ss.GwManager().Get("gateway")
+	}
+	if key.Uid {
+		return e.peerLocator(socket.ConnId{Id: key.Id, Type: "UID"})
+	}
+	return e.peerLocator(socket.ConnId{Id: key.Id, Type: "TARGET"})
+}
+
+func (e *Event) peerCountSessions(gw, rqId string, key SessionKey) (n int, err error) {
+	err = e.ss.GwManager().HostCall(e.ctx, gw, 0, "gateway", "gateway", rqId, "", "", func(ctx context.Context, cc *grpc.ClientConn) error {
+		resp, err1 := managev1.NewSessionServiceClient(cc).CountSessions(ctx, &managev1.CountSessionsRequest{
+			Key: toSessionKeyPb(key),
+		})
+		if err1 == nil {
+			n = int(resp.Count)
+		}
+		return err1
+	})
+	return
+}
+
+func (e *Event) peerKickSessions(gw, rqId string, key SessionKey, num int) (n int, err error) {
+	err = e.ss.GwManager().HostCall(e.ctx, gw, 0, "gateway", "gateway", rqId, "", "", func(ctx context.Context, cc *grpc.ClientConn) error {
+		resp, err1 := managev1.NewSessionServiceClient(cc).KickSessions(ctx, &managev1.KickSessionsRequest{
+			Key:    toSessionKeyPb(key),
+			Num:    int64(num),
+			Reason: kickedReason,
+		})
+		if err1 == nil {
+			n = int(resp.Kicked)
+		}
+		return err1
+	})
+	return
+}
+
+func toSessionKeyPb(key SessionKey) *managev1.SessionKey {
+	return &managev1.SessionKey{
+		Type: key.Type,
+		Id:   key.Id,
+		Uid:  key.Uid,
+	}
+}
@@ -0,0 +1,174 @@
+package eventhandler
+
+import (
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultMulticastConcurrency 多播的默认并发数
+const DefaultMulticastConcurrency = 64
+
+// fanoutKey 编码结果可以共享的连接: 数据编码名称、网关包编码及协议编码的类型都相同
+// 编码器由同一个provider按名称创建, 可能每个连接一个实例, 按类型而不是实例分组
+type fanoutKey struct {
+	coder codec.Name
+	pkg   reflect.Type
+	proto reflect.Type
+}
+
+// Multicast 以不超过concurrency的并发向多个连接发送同一action, 按连接协商的数据编码选择pbMsg或jsonMsg, jsonMsg为空时由网关转换, 返回发送成功的数量
+// 编码相同的连接只编码一次, 只有需要加密的连接逐个加密及协议编码, 使用原始协议的连接不投递
+func (e *Event) Multicast(conns []socket.Conn, rqId string, a codec.Action, pbMsg, jsonMsg []byte, concurrency int) int {
+	var jobs []func() bool
+	groups := make(map[fanoutKey][]socket.Conn)
+	var keys []fanoutKey
+	for _, c := range conns {
+		// 未完成编码协商的连接无法投递
+		if !connutil.CoderInitialized(c) {
+			continue
+		}
+		// 原始协议的设备无法解析网关包
+		if u := c.Context().Authentication(); u != nil && u.Protocol != "" {
+			e.log(c, rqId, "multicast skipped raw protocol connection:"+u.Protocol, zapcore.DebugLevel)
+			continue
+		}
+		key, ok := e.fanoutKey(c)
+		if !ok {
+			jobs = append(jobs, e.sendJob(c, rqId, a, pbMsg, jsonMsg))
+			continue
+		}
+		if _, ok = groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+	for _, key := range keys {
+		jobs = append(jobs, e.fanoutJobs(groups[key], rqId, a, pbMsg, jsonMsg)...)
+	}
+	return runJobs(jobs, concurrency)
+}
+
+// fanoutKey 返回连接的编码分组, 自定义网关包编码时不能共享
+func (e *Event) fanoutKey(c socket.Conn) (fanoutKey, bool) {
+	if e.packageCoder != nil {
+		return fanoutKey{}, false
+	}
+	pkg, proto := connutil.GatewayPkgCoder(c), connutil.ProtoCoder(c)
+	if pkg == nil || proto == nil {
+		return fanoutKey{}, false
+	}
+	return fanoutKey{coder: connutil.CoderName(c), pkg: reflect.TypeOf(pkg), proto: reflect.TypeOf(proto)}, true
+}
+
+// sendJob 逐个连接编码发送
+func (e *Event) sendJob(c socket.Conn, rqId string, a codec.Action, pbMsg, jsonMsg []byte) func() bool {
+	return func() bool {
+		msg, err := e.Payload(c, a, pbMsg, jsonMsg)
+		if err != nil {
+			e.log(c, rqId, "multicast payload failed, err="+err.Error(), zapcore.ErrorLevel)
+			return false
+		}
+		return e.Send(c, rqId, a, msg) == nil
+	}
+}
+
+// fanoutJobs 同一分组的连接共享网关包编码, 未加密的连接共享协议编码
+func (e *Event) fanoutJobs(conns []socket.Conn, rqId string, a codec.Action, pbMsg, jsonMsg []byte) (jobs []func() bool) {
+	first := conns[0]
+	msg, err := e.Payload(first, a, pbMsg, jsonMsg)
+	if err != nil {
+		e.log(first, rqId, "multicast payload failed, err="+err.Error(), zapcore.ErrorLevel)
+		return
+	}
+	pkg, err := e.actionEncode(first, &codec.PKG{Action: a.Id, Data: msg})
+	if err != nil {
+		e.log(first, rqId, "multicast gateway package encode failed, err="+err.Error(), zapcore.ErrorLevel)
+		return
+	}
+	var plain []byte
+	for _, c := range conns {
+		if e.Security() && len(e.CryptoKey(c)) > 0 {
+			jobs = append(jobs, func(c socket.Conn) func() bool {
+				return func() bool {
+					data, err1 := e.encrypt(c, pkg)
+					if err1 == nil {
+						data, err1 = e.codecEncode(c, data)
+					}
+					if err1 != nil {
+						e.log(c, rqId, "send action pack failed, err="+err1.Error(), zapcore.ErrorLevel)
+						return false
+					}
+					return e.sendPacked(c, rqId, a, data)
+				}
+			}(c))
+			continue
+		}
+		if plain == nil {
+			if plain, err = e.codecEncode(c, pkg); err != nil {
+				e.log(c, rqId, "multicast codec encode failed, err="+err.Error(), zapcore.ErrorLevel)
+				return
+			}
+		}
+		jobs = append(jobs, func(c socket.Conn, data []byte) func() bool {
+			return func() bool {
+				return e.sendPacked(c, rqId, a, data)
+			}
+		}(c, plain))
+	}
+	return
+}
+
+// sendPacked 发送已编码的数据
+func (e *Event) sendPacked(c socket.Conn, rqId string, a codec.Action, data []byte) bool {
+	if err := e.writeAction(c, a, data); err != nil {
+		e.log(c, rqId, "send action failed, err="+err.Error(), zapcore.ErrorLevel)
+		e.log(c, rqId, "send action failed data", zapcore.DebugLevel, zap.String("action", a.String()), zap.ByteString("package", data))
+		return false
+	}
+	e.log(c, "", "sent action["+a.String()+"]", zapcore.InfoLevel)
+	return true
+}
+
+// runJobs 以不超过concurrency的并发执行, 返回成功的数量
+func runJobs(jobs []func() bool, concurrency int) int {
+	if concurrency <= 0 {
+		concurrency = DefaultMulticastConcurrency
+	}
+	if concurrency > len(jobs) {
+		concurrency = len(jobs)
+	}
+	var sent int64
+	if concurrency == 1 {
+		for _, job := range jobs {
+			if job() {
+				sent++
+			}
+		}
+		return int(sent)
+	}
+	var wg sync.WaitGroup
+	ch := make(chan func() bool)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range ch {
+				if job() {
+					atomic.AddInt64(&sent, 1)
+				}
+			}
+		}()
+	}
+	for _, job := range jobs {
+		ch <- job
+	}
+	close(ch)
+	wg.Wait()
+	return int(sent)
+}
@@ -0,0 +1,121 @@
+package eventhandler
+
+import (
+	"bytes"
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"github.com/obnahsgnaw/socketgateway/service/action"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type multicastConn struct {
+	fd  int
+	ctx *socket.ConnContext
+	mu  sync.Mutex
+	out [][]byte
+}
+
+func (c *multicastConn) Fd() int                      { return c.fd }
+func (c *multicastConn) Context() *socket.ConnContext { return c.ctx }
+func (c *multicastConn) Read() ([]byte, error)        { return nil, nil }
+func (c *multicastConn) Close()                       {}
+func (c *multicastConn) LocalAddr() net.Addr          { return nil }
+func (c *multicastConn) RemoteAddr() net.Addr         { return nil }
+func (c *multicastConn) Write(b []byte) error {
+	c.mu.Lock()
+	c.out = append(c.out, b)
+	c.mu.Unlock()
+	return nil
+}
+
+// newMulticast 连接经由initCodec协商编码, 与线上一致, 每个连接的编码器由provider单独创建
+func newMulticast(n int) (*Event, []socket.Conn) {
+	e := New(context.Background(), action.NewManager(), sockettype.TCP)
+	conns := make([]socket.Conn, n)
+	for i := range conns {
+		c := &multicastConn{fd: i + 1, ctx: socket.NewContext()}
+		e.initCodec(c, "", codec.Json)
+		conns[i] = c
+	}
+	return e, conns
+}
+
+func TestMulticast(t *testing.T) {
+	e, conns := newMulticast(10)
+	a := codec.NewAction(101, "notice")
+	if sent := e.Multicast(conns, "", a, []byte("pb"), []byte(`{"title":"maintenance"}`), 3); sent != len(conns) {
+		t.Error("need sent to all", sent)
+		return
+	}
+	keys := make(map[fanoutKey]struct{})
+	for _, c := range conns {
+		key, ok := e.fanoutKey(c)
+		if !ok {
+			t.Error("need connections with negotiated codec grouped")
+			return
+		}
+		keys[key] = struct{}{}
+	}
+	if len(keys) != 1 {
+		t.Error("need connections of same codec share one group", len(keys))
+		return
+	}
+	want := conns[0].(*multicastConn).out[0]
+	for _, c := range conns {
+		c1 := &multicastConn{ctx: c.Context()}
+		_ = e.Send(c1, "", a, []byte(`{"title":"maintenance"}`))
+		if out := c.(*multicastConn).out; len(out) != 1 || !bytes.Equal(out[0], want) || !bytes.Equal(c1.out[0], want) {
+			t.Error("need same package as send")
+			return
+		}
+	}
+}
+
+func TestMulticastSkipRaw(t *testing.T) {
+	e, conns := newMulticast(3)
+	s := socket.New(context.Background(), sockettype.TCP, 0, nil, nil, nil, nil)
+	_ = s.Authenticate(conns[0], &socket.Authentication{Type: "device", Id: "1", Sn: "sn1", Protocol: "modbus"})
+	_ = s.Authenticate(conns[1], &socket.Authentication{Type: "device", Id: "2", Sn: "sn2"})
+	if sent := e.Multicast(conns, "", codec.NewAction(101, "notice"), []byte("pb"), []byte(`{}`), 2); sent != 2 {
+		t.Error("need skip raw protocol connection", sent)
+		return
+	}
+	if out := conns[0].(*multicastConn).out; len(out) != 0 {
+		t.Error("need not send gateway package to raw protocol connection")
+	}
+}
+
+func benchmarkBroadcast(b *testing.B, n int, send func(e *Event, conns []socket.Conn, a codec.Action, msg []byte)) {
+	e, conns := newMulticast(n)
+	a := codec.NewAction(101, "notice")
+	msg := []byte(`{"title":"maintenance","content":"` + strconv.Itoa(n) + `"}`)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		send(e, conns, a, msg)
+		for _, c := range conns {
+			c.(*multicastConn).out = nil
+		}
+	}
+}
+
+// BenchmarkBroadcastSend 原有路径, 逐个成员调用Send
+func BenchmarkBroadcastSend(b *testing.B) {
+	benchmarkBroadcast(b, 500, func(e *Event, conns []socket.Conn, a codec.Action, msg []byte) {
+		for _, c := range conns {
+			_ = e.Send(c, "", a, msg)
+		}
+	})
+}
+
+// BenchmarkBroadcastMulticast 编码一次后发送
+func BenchmarkBroadcastMulticast(b *testing.B) {
+	benchmarkBroadcast(b, 500, func(e *Event, conns []socket.Conn, a codec.Action, msg []byte) {
+		e.Multicast(conns, "", a, nil, msg, 1)
+	})
+}
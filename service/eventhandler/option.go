@@ -1,8 +1,10 @@
 package eventhandler
 
 import (
+	"context"
 	"github.com/obnahsgnaw/goutils/security/coder"
 	"github.com/obnahsgnaw/goutils/security/esutil"
+	"github.com/obnahsgnaw/socketgateway/pkg/abuse"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"github.com/obnahsgnaw/socketgateway/service/manage"
 	"github.com/obnahsgnaw/socketutil/codec"
@@ -15,15 +17,67 @@ type Option func(event *Event)
 func AuthCheck(interval time.Duration) Option {
 	return func(event *Event) {
 		if interval > 0 {
-			event.AddTicker("auth-ticker", authTicker(interval))
+			event.addTicker(event.authTicker(interval))
 		}
 	}
 }
 
+func AuthExpireCheck(demote bool) Option {
+	return func(event *Event) {
+		event.addTicker(event.authExpireTicker(demote))
+	}
+}
+
+// TypeLoginPolicy 设置认证类型的多端登录策略
+func TypeLoginPolicy(tp string, p LoginPolicy) Option {
+	return func(event *Event) {
+		event.loginPolicies[tp] = p
+	}
+}
+
+// UidLoginPolicy 设置同一用户id的多端登录策略
+func UidLoginPolicy(p LoginPolicy) Option {
+	return func(event *Event) {
+		event.uidLoginPolicy = p
+	}
+}
+
+// ProxyProtocol 前置代理使用proxy protocol, 首包未携带代理头的连接将被关闭
+func ProxyProtocol() Option {
+	return func(event *Event) {
+		event.proxyProtocol = true
+	}
+}
+
+// RawRouter 设置原始数据的跨网关转发, 设备上行的子动作目标(SN)不在当前网关时调用
+func RawRouter(route func(ctx context.Context, rqId, target string, data []byte) error) Option {
+	return func(event *Event) {
+		event.rawRouter = route
+	}
+}
+
+// PeerLocator 设置集群在线索引的查询, 返回持有id的其他网关, 用于只向这些网关发起调用
+func PeerLocator(locate func(id socket.ConnId) []string) Option {
+	return func(event *Event) {
+		event.peerLocator = locate
+	}
+}
+
 func Heartbeat(interval time.Duration) Option {
 	return func(event *Event) {
 		if interval > 0 {
-			event.AddTicker("heartbeat-ticker", heartbeatTicker(interval))
+			event.heartbeatInterval = interval
+			event.addTicker(event.heartbeatTicker(interval))
+		}
+	}
+}
+
+// ActiveHeartbeat 主动心跳, 连接静默超过interval时发送探测, 连续maxMissed次无响应后踢掉, 替换Heartbeat的被动检查
+func ActiveHeartbeat(interval time.Duration, maxMissed int) Option {
+	return func(event *Event) {
+		if interval > 0 {
+			event.heartbeatInterval = interval
+			event.addTicker(event.activeHeartbeatTicker(maxMissed))
 		}
 	}
 }
@@ -32,7 +86,7 @@ func Auth(authProvider AuthProvider) Option {
 	return func(event *Event) {
 		event.authEnable = true
 		event.authProvider = authProvider
-		event.AddTicker("auth-ticker", authTicker(time.Second*10))
+		event.addTicker(event.authTicker(time.Second * 10))
 	}
 }
 
@@ -45,12 +99,20 @@ func DefaultUser(u *socket.AuthUser) Option {
 	}
 }
 
+// Tick 设置时间轮的精度及定时任务的默认间隔
 func Tick(interval time.Duration) Option {
 	return func(event *Event) {
 		event.tickInterval = interval
 	}
 }
 
+// TickWorkers 设置执行连接定时任务的协程数
+func TickWorkers(n int) Option {
+	return func(event *Event) {
+		event.tickWorkers = n
+	}
+}
+
 func SecPrivateKey(key []byte) Option {
 	return func(event *Event) {
 		event.commonPrivateKey = key
@@ -114,9 +176,32 @@ func Ticker(name string, ticker TickHandler) Option {
 	}
 }
 
+// IntervalTicker 添加按interval执行的连接定时任务
+func IntervalTicker(name string, interval time.Duration, ticker TickHandler) Option {
+	return func(event *Event) {
+		event.AddIntervalTicker(name, interval, ticker)
+	}
+}
+
 func Interceptor(i func() error) Option {
 	return func(event *Event) {
-		event.openInterceptor = i
+		if i != nil {
+			event.AppendOpenInterceptor(func(socket.Conn) error {
+				return i()
+			})
+		}
+	}
+}
+
+func OpenInterceptor(i OpenFunc) Option {
+	return func(event *Event) {
+		event.AppendOpenInterceptor(i)
+	}
+}
+
+func AbuseDetector(d *abuse.Detector) Option {
+	return func(event *Event) {
+		event.abuse = d
 	}
 }
 
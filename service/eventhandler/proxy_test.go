@@ -0,0 +1,58 @@
+package eventhandler
+
+import (
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/abuse"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"testing"
+	"time"
+)
+
+func newProxyEvent(proxy bool) (*Event, socket.Conn) {
+	e, conns := newMulticast(1)
+	e.proxyProtocol = proxy
+	e.ss = socket.New(context.Background(), sockettype.TCP, 0, nil, nil, nil, nil)
+	return e, conns[0]
+}
+
+func TestProxyHeaderIgnoredWithoutProxyProtocol(t *testing.T) {
+	e, c := newProxyEvent(false)
+	if !e.resolveProxy(c, "", []byte("PROXY TCP4 1.2.3.4 10.0.0.1 5678 80\r\n")) {
+		t.Error("need pass without proxy protocol")
+		return
+	}
+	if c.Context().RealIp() != "" || socket.ConnIp(c) != "" {
+		t.Errorf("need ignore proxy header from direct client, got %q", c.Context().RealIp())
+	}
+}
+
+func TestProxyHeaderFirstPacketOnly(t *testing.T) {
+	e, c := newProxyEvent(true)
+	if !e.resolveProxy(c, "", []byte("PROXY TCP4 1.2.3.4 10.0.0.1 5678 80\r\n")) || c.Context().RealIp() != "1.2.3.4" {
+		t.Errorf("need resolve real ip, got %q", c.Context().RealIp())
+		return
+	}
+	if !e.resolveProxy(c, "", []byte("PROXY TCP4 5.6.7.8 10.0.0.1 5678 80\r\n")) || c.Context().RealIp() != "1.2.3.4" {
+		t.Errorf("need ignore header after first packet, got %q", c.Context().RealIp())
+		return
+	}
+	if err := e.ss.SetRealIp(c, "5.6.7.8"); !errors.Is(err, socket.ErrRealIpSet) || c.Context().RealIp() != "1.2.3.4" {
+		t.Errorf("need refuse second real ip, got %v", err)
+	}
+}
+
+func TestProxyRealIpBanned(t *testing.T) {
+	e, c := newProxyEvent(true)
+	e.abuse = abuse.New(abuse.Config{})
+	e.abuse.Ban("1.2.3.4", time.Minute)
+	// 连接建立时只能看到代理的地址, 封禁检查在解析出真实ip后执行
+	if !e.realIpIntercept(c) {
+		t.Error("need pass before real ip resolved")
+		return
+	}
+	if e.resolveProxy(c, "", []byte("PROXY TCP4 1.2.3.4 10.0.0.1 5678 80\r\n")) {
+		t.Error("need reject banned real ip")
+	}
+}
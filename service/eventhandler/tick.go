@@ -2,45 +2,199 @@ package eventhandler
 
 import (
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/timewheel"
+	"github.com/obnahsgnaw/socketgateway/service/action"
 	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
+	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"time"
 )
 
+// TickHandler 连接的定时任务, 每个连接独立按间隔执行, 返回false时该连接不再执行
 type TickHandler func(*socket.Server, socket.Conn) bool
 
-// 连接x秒后未认证 踢掉
-func authTicker(ttl time.Duration) TickHandler {
-	return func(server *socket.Server, conn socket.Conn) bool {
-		if !conn.Context().Authed() && conn.Context().ConnectedAt().Add(ttl).Before(time.Now()) {
-			connutil.SetCloseReason(conn, "close by auth checker")
-			conn.Close()
+// connTimer 连接的定时任务, 返回下次执行的延迟, 不大于0时不再执行
+type connTimer func(s *socket.Server, c socket.Conn) time.Duration
+
+type ticker struct {
+	name  string
+	first func(c socket.Conn) time.Duration // 连接建立后首次执行的延迟
+	run   connTimer
+}
+
+func fixed(d time.Duration) func(socket.Conn) time.Duration {
+	return func(socket.Conn) time.Duration {
+		return d
+	}
+}
+
+// AddTicker 添加按Tick间隔执行的连接定时任务, 同名的会被替换
+func (e *Event) AddTicker(name string, h TickHandler) {
+	e.AddIntervalTicker(name, 0, h)
+}
+
+// AddIntervalTicker 添加按interval执行的连接定时任务, interval为0时使用Tick间隔, 同名的会被替换
+func (e *Event) AddIntervalTicker(name string, interval time.Duration, h TickHandler) {
+	e.addTicker(ticker{
+		name: name,
+		first: func(socket.Conn) time.Duration {
+			return e.tickerInterval(interval)
+		},
+		run: func(s *socket.Server, c socket.Conn) time.Duration {
+			if h(s, c) {
+				return e.tickerInterval(interval)
+			}
+			return 0
+		},
+	})
+}
+
+func (e *Event) addTicker(t ticker) {
+	for i := range e.tickers {
+		if e.tickers[i].name == t.name {
+			e.tickers[i] = t
+			return
 		}
-		return true
 	}
+	e.tickers = append(e.tickers, t)
 }
 
-// 连接x秒后未认证 踢掉
-func authenticateTicker(ttl time.Duration) TickHandler {
-	return func(server *socket.Server, conn socket.Conn) bool {
-		if !conn.Context().Authenticated() && conn.Context().ConnectedAt().Add(ttl).Before(time.Now()) {
-			connutil.SetCloseReason(conn, "close by authenticate checker")
-			conn.Close()
+func (e *Event) tickerInterval(interval time.Duration) time.Duration {
+	if interval > 0 {
+		return interval
+	}
+	if e.tickInterval > 0 {
+		return e.tickInterval
+	}
+	return timewheel.DefaultTick
+}
+
+// DefaultTickWorkers 执行连接定时任务的默认协程数
+const DefaultTickWorkers = 64
+
+// startWheel 有定时任务时才启动时间轮, 定时任务中有写连接等io, 交由工作池执行
+func (e *Event) startWheel() {
+	e.wheelOnce.Do(func() {
+		workers := e.tickWorkers
+		if workers <= 0 {
+			workers = DefaultTickWorkers
 		}
-		return true
+		e.wheel = timewheel.New(e.tickInterval, timewheel.Workers(workers, workers*64), timewheel.PanicHandler(func(err, stack string) {
+			e.serverErrorLog("on tick panic, err=", err, ", stack=", stack)
+		}))
+		go e.wheel.Run(e.ctx)
+	})
+}
+
+// startTimers 连接建立后按添加顺序在时间轮上调度各定时任务
+func (e *Event) startTimers(c socket.Conn) {
+	if len(e.tickers) == 0 {
+		return
+	}
+	e.startWheel()
+	timers := make([]*timewheel.Timer, 0, len(e.tickers))
+	for _, t := range e.tickers {
+		var tm *timewheel.Timer
+		run := t.run
+		tm = e.wheel.NewTimer(func() {
+			if next := run(e.ss, c); next > 0 {
+				tm.Reset(next)
+			}
+		})
+		timers = append(timers, tm)
+	}
+	c.Context().SetOptional("timers", timers)
+	for i, t := range e.tickers {
+		timers[i].Reset(t.first(c))
 	}
 }
 
-// 心跳检查时间内未发送数据 踢掉
-func heartbeatTicker(ttl time.Duration) TickHandler {
-	return func(server *socket.Server, conn socket.Conn) bool {
-		v := connutil.GetHeartbeatInterval(conn)
-		if v > 0 {
-			ttl = time.Duration(v) * time.Second
+func (e *Event) stopTimers(c socket.Conn) {
+	if v, ok := c.Context().GetOptional("timers"); ok {
+		c.Context().DelOptional("timers")
+		for _, tm := range v.([]*timewheel.Timer) {
+			tm.Stop()
 		}
-		if conn.Context().LastActiveAt().Add(ttl).Before(time.Now()) {
-			connutil.SetCloseReason(conn, "close by heartbeat checker")
-			conn.Close()
+	}
+}
+
+// 连接x秒后未认证 踢掉
+func (e *Event) authTicker(ttl time.Duration) ticker {
+	return ticker{
+		name:  "auth-ticker",
+		first: fixed(ttl),
+		run: func(server *socket.Server, conn socket.Conn) time.Duration {
+			if !conn.Context().Authed() {
+				e.Disconnect(conn, gatewayv1.DisconnectNotice_AuthTimeout, "close by auth checker")
+			}
+			return 0
+		},
+	}
+}
+
+// 认证过期后通知客户端, 然后踢掉或降级为未认证, 设置了过期时间时在过期时检查
+func (e *Event) authExpireTicker(demote bool) ticker {
+	return ticker{
+		name: "auth-expire-ticker",
+		first: func(socket.Conn) time.Duration {
+			return e.tickerInterval(0)
+		},
+		run: func(server *socket.Server, conn socket.Conn) time.Duration {
+			interval := e.tickerInterval(0)
+			if !conn.Context().Authed() {
+				return interval
+			}
+			if !conn.Context().AuthExpired() {
+				if at := conn.Context().AuthExpireAt(); !at.IsZero() {
+					return time.Until(at)
+				}
+				return interval
+			}
+			_ = e.SendAction(conn, action.New(gatewayv1.ActionId_AuthExpired), &gatewayv1.AuthExpiredNotice{
+				ExpiredAt: timestamppb.New(conn.Context().AuthExpireAt()),
+				Closing:   !demote,
+			})
+			if demote {
+				server.Auth(conn, nil)
+				return interval
+			}
+			e.Disconnect(conn, gatewayv1.DisconnectNotice_AuthExpired, "close by auth expire checker")
+			return 0
+		},
+	}
+}
+
+// 连接x秒后未认证 踢掉
+func (e *Event) authenticateTicker(ttl time.Duration) ticker {
+	return ticker{
+		name:  "authenticate-ticker",
+		first: fixed(ttl),
+		run: func(server *socket.Server, conn socket.Conn) time.Duration {
+			if !conn.Context().Authenticated() {
+				e.Disconnect(conn, gatewayv1.DisconnectNotice_AuthenticateTimeout, "close by authenticate checker")
+			}
+			return 0
+		},
+	}
+}
+
+// 心跳检查时间内未发送数据 踢掉, 期间有数据时顺延到最后活跃时间加检查时间
+func (e *Event) heartbeatTicker(ttl time.Duration) ticker {
+	connTtl := func(conn socket.Conn) time.Duration {
+		if v := connutil.GetHeartbeatInterval(conn); v > 0 {
+			return time.Duration(v) * time.Second
 		}
-		return true
+		return ttl
+	}
+	return ticker{
+		name:  "heartbeat-ticker",
+		first: connTtl,
+		run: func(server *socket.Server, conn socket.Conn) time.Duration {
+			if remain := time.Until(conn.Context().LastActiveAt().Add(connTtl(conn))); remain > 0 {
+				return remain
+			}
+			e.Disconnect(conn, gatewayv1.DisconnectNotice_HeartbeatTimeout, "close by heartbeat checker")
+			return 0
+		},
 	}
 }
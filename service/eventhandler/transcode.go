@@ -0,0 +1,30 @@
+package eventhandler
+
+import (
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
+	"github.com/obnahsgnaw/socketutil/codec"
+)
+
+var ErrNoJsonMessage = errors.New("json message is empty and action not transcodable")
+
+// Transcodable action的proto数据是否可以由网关转换为json
+func (e *Event) Transcodable(id codec.ActionId) bool {
+	t := e.am.Transcoder()
+	return t != nil && t.Transcodable(id)
+}
+
+// Payload 按连接协商的数据编码选择pbMsg或jsonMsg, jsonMsg为空时由网关将pbMsg转换为json
+func (e *Event) Payload(c socket.Conn, a codec.Action, pbMsg, jsonMsg []byte) ([]byte, error) {
+	if connutil.CoderName(c) == codec.Proto {
+		return pbMsg, nil
+	}
+	if len(jsonMsg) > 0 {
+		return jsonMsg, nil
+	}
+	if !e.Transcodable(a.Id) {
+		return nil, ErrNoJsonMessage
+	}
+	return e.am.Transcoder().ToJson(a.Id, pbMsg)
+}
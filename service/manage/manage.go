@@ -2,7 +2,9 @@ package manage
 
 import (
 	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/abuse"
 	"github.com/obnahsgnaw/socketgateway/pkg/group"
+	"github.com/obnahsgnaw/socketgateway/pkg/ipfilter"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"github.com/obnahsgnaw/socketutil/codec"
 	"strconv"
@@ -17,6 +19,29 @@ type Manager struct {
 	onofflineListener func(*Connection, bool)
 	fdMessageListener func(*Message)
 	sender            func(c socket.Conn, act codec.Action, pkg []byte) error
+	ipFilter          *ipfilter.Filter
+	abuse             *abuse.Detector
+}
+
+type Option func(*Manager)
+
+// IpFilter 管理的ip黑白名单
+func IpFilter(f *ipfilter.Filter) Option {
+	return func(m *Manager) {
+		m.ipFilter = f
+	}
+}
+
+// AbuseDetector 管理的异常流量检测
+func AbuseDetector(d *abuse.Detector) Option {
+	return func(m *Manager) {
+		m.abuse = d
+	}
+}
+
+type IpFilterRules struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
 }
 
 type Gateway struct {
@@ -49,13 +74,16 @@ type Connection struct {
 	Binds          map[string]string
 }
 
-func New(protocol string, s *socket.Server, config *GwConfig, sender func(c socket.Conn, act codec.Action, pkg []byte) error) *Manager {
+func New(protocol string, s *socket.Server, config *GwConfig, sender func(c socket.Conn, act codec.Action, pkg []byte) error, options ...Option) *Manager {
 	m := &Manager{
 		protocol: protocol,
 		s:        s,
 		config:   config,
 		sender:   sender,
 	}
+	for _, o := range options {
+		o(m)
+	}
 	return m
 }
 
@@ -101,13 +129,84 @@ func (m *Manager) Groups() (list []string) {
 }
 
 func (m *Manager) GroupMembers(name string) (list []string) {
-	m.s.Groups().GetGroup(name).RangeMembers(func(fd int, id string) bool {
+	g, ok := m.s.Groups().Group(name)
+	if !ok {
+		return
+	}
+	g.RangeMembers(func(fd int, id string) bool {
 		list = append(list, id+"["+strconv.Itoa(fd)+"]")
 		return true
 	})
 	return
 }
 
+func (m *Manager) IpFilter() (rules *IpFilterRules, err error) {
+	if m.ipFilter == nil {
+		err = errors.New("ip filter not enabled")
+		return
+	}
+	rules = &IpFilterRules{
+		Allow: m.ipFilter.Allows(),
+		Deny:  m.ipFilter.Denies(),
+	}
+	return
+}
+
+func (m *Manager) IpFilterSet(rules IpFilterRules) (*IpFilterRules, error) {
+	if m.ipFilter == nil {
+		return nil, errors.New("ip filter not enabled")
+	}
+	if err := m.ipFilter.Set(rules.Allow, rules.Deny); err != nil {
+		return nil, err
+	}
+	return m.IpFilter()
+}
+
+func (m *Manager) IpFilterAdd(rules IpFilterRules) (*IpFilterRules, error) {
+	if m.ipFilter == nil {
+		return nil, errors.New("ip filter not enabled")
+	}
+	if err := m.ipFilter.Add(rules.Allow, rules.Deny); err != nil {
+		return nil, err
+	}
+	return m.IpFilter()
+}
+
+func (m *Manager) IpFilterRemove(rules IpFilterRules) (*IpFilterRules, error) {
+	if m.ipFilter == nil {
+		return nil, errors.New("ip filter not enabled")
+	}
+	if err := m.ipFilter.Remove(rules.Allow, rules.Deny); err != nil {
+		return nil, err
+	}
+	return m.IpFilter()
+}
+
+func (m *Manager) Bans() (list []abuse.Ban, err error) {
+	if m.abuse == nil {
+		err = errors.New("abuse detect not enabled")
+		return
+	}
+	list = m.abuse.Bans()
+	return
+}
+
+func (m *Manager) Ban(ip string, duration time.Duration) error {
+	if m.abuse == nil {
+		return errors.New("abuse detect not enabled")
+	}
+	m.abuse.Ban(ip, duration)
+	return nil
+}
+
+func (m *Manager) Unban(ip string) error {
+	if m.abuse == nil {
+		return errors.New("abuse detect not enabled")
+	}
+	m.abuse.Unban(ip)
+	return nil
+}
+
 func (m *Manager) toConnection(c socket.Conn) *Connection {
 	return &Connection{
 		Fd:             c.Fd(),
@@ -46,12 +46,21 @@ const (
 type ActionId int32
 
 const (
-	ActionId_None       ActionId = 0
-	ActionId_GatewayErr ActionId = 1  //
-	ActionId_Ping       ActionId = 11 // ping 测试
-	ActionId_Pong       ActionId = 12 // pong 响应
-	ActionId_AuthReq    ActionId = 13 // 认证的请求
-	ActionId_AuthResp   ActionId = 14 // 认证的响应
+	ActionId_None            ActionId = 0
+	ActionId_GatewayErr      ActionId = 1  //
+	ActionId_Ping            ActionId = 11 // ping 测试
+	ActionId_Pong            ActionId = 12 // pong 响应
+	ActionId_AuthReq         ActionId = 13 // 认证的请求
+	ActionId_AuthResp        ActionId = 14 // 认证的响应
+	ActionId_AuthExpired     ActionId = 15 // 认证过期的通知
+	ActionId_AuthRefreshReq  ActionId = 16 // 刷新认证token的请求
+	ActionId_AuthRefreshResp ActionId = 17 // 刷新认证token的响应
+	ActionId_Kicked          ActionId = 18 // 被踢下线的通知
+	ActionId_SubscribeReq    ActionId = 19 // 订阅主题的请求
+	ActionId_SubscribeResp   ActionId = 20 // 订阅主题的响应
+	ActionId_UnsubscribeReq  ActionId = 21 // 取消订阅主题的请求
+	ActionId_UnsubscribeResp ActionId = 22 // 取消订阅主题的响应
+	ActionId_Disconnect      ActionId = 23 // 网关主动断开连接的通知
 )
 
 // Enum value maps for ActionId.
@@ -63,14 +72,32 @@ var (
 		12: "Pong",
 		13: "AuthReq",
 		14: "AuthResp",
+		15: "AuthExpired",
+		16: "AuthRefreshReq",
+		17: "AuthRefreshResp",
+		18: "Kicked",
+		19: "SubscribeReq",
+		20: "SubscribeResp",
+		21: "UnsubscribeReq",
+		22: "UnsubscribeResp",
+		23: "Disconnect",
 	}
 	ActionId_value = map[string]int32{
-		"None":       0,
-		"GatewayErr": 1,
-		"Ping":       11,
-		"Pong":       12,
-		"AuthReq":    13,
-		"AuthResp":   14,
+		"None":            0,
+		"GatewayErr":      1,
+		"Ping":            11,
+		"Pong":            12,
+		"AuthReq":         13,
+		"AuthResp":        14,
+		"AuthExpired":     15,
+		"AuthRefreshReq":  16,
+		"AuthRefreshResp": 17,
+		"Kicked":          18,
+		"SubscribeReq":    19,
+		"SubscribeResp":   20,
+		"UnsubscribeReq":  21,
+		"UnsubscribeResp": 22,
+		"Disconnect":      23,
 	}
 )
 
@@ -106,23 +133,34 @@ var File_gateway_v1_actid_proto protoreflect.FileDescriptor
 var file_gateway_v1_actid_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x74,
 	0x69, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
-	0x79, 0x2e, 0x76, 0x31, 0x2a, 0x53, 0x0a, 0x08, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
-	0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x47, 0x61,
-	0x74, 0x65, 0x77, 0x61, 0x79, 0x45, 0x72, 0x72, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x69,
-	0x6e, 0x67, 0x10, 0x0b, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x6f, 0x6e, 0x67, 0x10, 0x0c, 0x12, 0x0b,
-	0x0a, 0x07, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x10, 0x0d, 0x12, 0x0c, 0x0a, 0x08, 0x41,
-	0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x10, 0x0e, 0x42, 0xab, 0x01, 0x0a, 0x0e, 0x63, 0x6f,
-	0x6d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x41, 0x63,
-	0x74, 0x69, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6e, 0x61, 0x68, 0x73, 0x67, 0x6e, 0x61,
-	0x77, 0x2f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x2f, 0x76, 0x31, 0x3b, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x76, 0x31,
-	0xa2, 0x02, 0x03, 0x47, 0x58, 0x58, 0xaa, 0x02, 0x0a, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
-	0x2e, 0x56, 0x31, 0xca, 0x02, 0x0a, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5c, 0x56, 0x31,
-	0xe2, 0x02, 0x16, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50,
-	0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0b, 0x47, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x79, 0x2e, 0x76, 0x31, 0x2a, 0xf7, 0x01, 0x0a, 0x08, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x47,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x45, 0x72, 0x72, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x50,
+	0x69, 0x6e, 0x67, 0x10, 0x0b, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x6f, 0x6e, 0x67, 0x10, 0x0c, 0x12,
+	0x0b, 0x0a, 0x07, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x10, 0x0d, 0x12, 0x0c, 0x0a, 0x08,
+	0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x10, 0x0e, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x75,
+	0x74, 0x68, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x10, 0x0f, 0x12, 0x12, 0x0a, 0x0e, 0x41,
+	0x75, 0x74, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x52, 0x65, 0x71, 0x10, 0x10, 0x12,
+	0x13, 0x0a, 0x0f, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x10, 0x11, 0x12, 0x0a, 0x0a, 0x06, 0x4b, 0x69, 0x63, 0x6b, 0x65, 0x64, 0x10, 0x12,
+	0x12, 0x10, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71,
+	0x10, 0x13, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x10, 0x14, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x10, 0x15, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x6e, 0x73,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70, 0x10, 0x16, 0x12, 0x0e,
+	0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x10, 0x17, 0x42, 0xab,
+	0x01, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x42, 0x0a, 0x41, 0x63, 0x74, 0x69, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
+	0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6e, 0x61,
+	0x68, 0x73, 0x67, 0x6e, 0x61, 0x77, 0x2f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x67, 0x65, 0x6e,
+	0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x76, 0x31, 0x3b, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x47, 0x58, 0x58, 0xaa, 0x02, 0x0a, 0x47, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0a, 0x47, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x16, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5c,
+	0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
+	0x0b, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -4,6 +4,7 @@ import (
 	"context"
 	bindv1 "github.com/obnahsgnaw/socketapi/gen/bind/v1"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -11,15 +12,38 @@ import (
 type BindService struct {
 	bindv1.UnimplementedBindServiceServer
 	s func() *socket.Server
+	t *tenant.Guard
 }
 
-func NewBindService(s func() *socket.Server) *BindService {
+func NewBindService(s func() *socket.Server, t *tenant.Guard) *BindService {
 	return &BindService{
 		s: s,
+		t: t,
 	}
 }
 
-func (gw *BindService) BindId(_ context.Context, in *bindv1.BindIdRequest) (resp *bindv1.BindIdResponse, err error) {
+// tenantConn 检查连接是否属于调用方租户
+func (gw *BindService) tenantConn(ctx context.Context, method string, conn socket.Conn) error {
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return err
+	}
+	if !gw.t.Allowed(cid, conn, tenant.Violation{Method: method, RqId: rqIdFrom(ctx)}) {
+		return status.New(codes.PermissionDenied, "connection of other tenant").Err()
+	}
+	return nil
+}
+
+// tenantConns 过滤出属于调用方租户的连接
+func (gw *BindService) tenantConns(ctx context.Context, method, target string, list []socket.Conn) ([]socket.Conn, error) {
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return nil, err
+	}
+	return gw.t.Filter(cid, list, tenant.Violation{Method: method, RqId: rqIdFrom(ctx), Target: target}), nil
+}
+
+func (gw *BindService) BindId(ctx context.Context, in *bindv1.BindIdRequest) (resp *bindv1.BindIdResponse, err error) {
 	if in.Fd == 0 {
 		err = status.New(codes.InvalidArgument, "param:fd is required").Err()
 		return
@@ -39,6 +63,9 @@ func (gw *BindService) BindId(_ context.Context, in *bindv1.BindIdRequest) (resp
 		err = status.New(codes.NotFound, "connection not found").Err()
 		return
 	}
+	if err = gw.tenantConn(ctx, "BindId", conn); err != nil {
+		return
+	}
 	for _, id := range in.Ids {
 		gw.s().BindId(conn, socket.ConnId{
 			Id:   id.Id,
@@ -49,7 +76,7 @@ func (gw *BindService) BindId(_ context.Context, in *bindv1.BindIdRequest) (resp
 	return
 }
 
-func (gw *BindService) BindExist(_ context.Context, in *bindv1.BindExistRequest) (resp *bindv1.BindExistResponse, err error) {
+func (gw *BindService) BindExist(ctx context.Context, in *bindv1.BindExistRequest) (resp *bindv1.BindExistResponse, err error) {
 	if in.Id == nil || in.Id.Typ == "" || in.Id.Id == "" {
 		err = status.New(codes.InvalidArgument, "param:id is required").Err()
 		return
@@ -58,11 +85,14 @@ func (gw *BindService) BindExist(_ context.Context, in *bindv1.BindExistRequest)
 		Id:   in.Id.Id,
 		Type: in.Id.Typ,
 	})
+	if conn, err = gw.tenantConns(ctx, "BindExist", in.Id.Id, conn); err != nil {
+		return
+	}
 	resp = &bindv1.BindExistResponse{Exist: len(conn) > 0}
 	return
 }
 
-func (gw *BindService) UnBindId(_ context.Context, in *bindv1.UnBindIdRequest) (resp *bindv1.UnBindIdResponse, err error) {
+func (gw *BindService) UnBindId(ctx context.Context, in *bindv1.UnBindIdRequest) (resp *bindv1.UnBindIdResponse, err error) {
 	if in.Fd == 0 {
 		err = status.New(codes.InvalidArgument, "param:fd is required").Err()
 		return
@@ -76,6 +106,9 @@ func (gw *BindService) UnBindId(_ context.Context, in *bindv1.UnBindIdRequest) (
 		err = status.New(codes.NotFound, "connection not found").Err()
 		return
 	}
+	if err = gw.tenantConn(ctx, "UnBindId", conn); err != nil {
+		return
+	}
 	for _, typ := range in.Types {
 		gw.s().UnbindTypedId(conn, typ)
 	}
@@ -83,36 +116,49 @@ func (gw *BindService) UnBindId(_ context.Context, in *bindv1.UnBindIdRequest) (
 	return
 }
 
-func (gw *BindService) DisconnectTarget(_ context.Context, in *bindv1.DisconnectTargetRequest) (resp *bindv1.DisconnectTargetResponse, err error) {
+func (gw *BindService) DisconnectTarget(ctx context.Context, in *bindv1.DisconnectTargetRequest) (resp *bindv1.DisconnectTargetResponse, err error) {
 	resp = &bindv1.DisconnectTargetResponse{}
 	if in.Id == "" {
 		err = status.New(codes.InvalidArgument, "param:id is required").Err()
 		return
 	}
-	conn := gw.s().GetAuthenticatedConn(in.Id)
+	conn, err := gw.tenantConns(ctx, "DisconnectTarget", in.Id, gw.s().GetAuthenticatedConn(in.Id))
+	if err != nil {
+		return
+	}
 	for _, c := range conn {
 		c.Close()
 	}
 	return
 }
 
-func (gw *BindService) BindProxyTarget(_ context.Context, in *bindv1.ProxyTargetRequest) (resp *bindv1.ProxyTargetResponse, err error) {
+func (gw *BindService) BindProxyTarget(ctx context.Context, in *bindv1.ProxyTargetRequest) (resp *bindv1.ProxyTargetResponse, err error) {
 	resp = &bindv1.ProxyTargetResponse{}
+	if conn := gw.s().GetFdConn(int(in.Fd)); conn != nil {
+		if err = gw.tenantConn(ctx, "BindProxyTarget", conn); err != nil {
+			return
+		}
+	}
 	for _, t := range in.Target {
 		gw.s().BindProxyTarget(t, int(in.Fd))
 	}
 	return
 }
 
-func (gw *BindService) UnbindProxyTarget(_ context.Context, in *bindv1.ProxyTargetRequest) (resp *bindv1.ProxyTargetResponse, err error) {
+func (gw *BindService) UnbindProxyTarget(ctx context.Context, in *bindv1.ProxyTargetRequest) (resp *bindv1.ProxyTargetResponse, err error) {
 	resp = &bindv1.ProxyTargetResponse{}
+	if conn := gw.s().GetFdConn(int(in.Fd)); conn != nil {
+		if err = gw.tenantConn(ctx, "UnbindProxyTarget", conn); err != nil {
+			return
+		}
+	}
 	for _, t := range in.Target {
 		gw.s().UnbindProxyTarget(t, int(in.Fd))
 	}
 	return
 }
 
-func (gw *BindService) TargetBindId(_ context.Context, in *bindv1.TargetBindIdRequest) (resp *bindv1.TargetBindIdResponse, err error) {
+func (gw *BindService) TargetBindId(ctx context.Context, in *bindv1.TargetBindIdRequest) (resp *bindv1.TargetBindIdResponse, err error) {
 	resp = &bindv1.TargetBindIdResponse{}
 	if in.Target == "" {
 		err = status.New(codes.InvalidArgument, "param:target is required").Err()
@@ -144,9 +190,9 @@ func (gw *BindService) TargetBindId(_ context.Context, in *bindv1.TargetBindIdRe
 				}
 			}
 		}
-		if len(cc) == 0 {
-			return
-		}
+	}
+	if cc, err = gw.tenantConns(ctx, "TargetBindId", in.Target, cc); err != nil || len(cc) == 0 {
+		return
 	}
 	idMap := cc[0].Context().IdMap()
 	if v, ok := idMap[in.BindType]; ok {
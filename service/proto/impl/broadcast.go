@@ -0,0 +1,130 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type BroadcastService struct {
+	managev1.UnimplementedBroadcastServiceServer
+	s           func() *socket.Server
+	e           func() *eventhandler.Event
+	t           *tenant.Guard
+	r           *Router
+	concurrency int
+}
+
+// NewBroadcastService concurrency为当前网关发送的并发数, r不为nil时支持广播到其他网关
+func NewBroadcastService(s func() *socket.Server, e func() *eventhandler.Event, t *tenant.Guard, r *Router, concurrency int) *BroadcastService {
+	return &BroadcastService{
+		s:           s,
+		e:           e,
+		t:           t,
+		r:           r,
+		concurrency: concurrency,
+	}
+}
+
+func (gw *BroadcastService) BroadcastFilter(ctx context.Context, in *managev1.BroadcastFilterRequest) (resp *managev1.BroadcastFilterResponse, err error) {
+	if in.GetActionId() == 0 {
+		err = status.New(codes.InvalidArgument, "param:ActionId is required").Err()
+		return
+	}
+	if len(in.GetPbMessage()) == 0 || (len(in.GetJsonMessage()) == 0 && !gw.e().Transcodable(codec.ActionId(in.GetActionId()))) {
+		err = status.New(codes.InvalidArgument, "param:Message is required").Err()
+		return
+	}
+	f := ToConnFilter(in.GetFilter())
+	if f.Empty() {
+		err = status.New(codes.InvalidArgument, "param:Filter is required").Err()
+		return
+	}
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return
+	}
+	// 开启隔离时只能广播到调用方租户的连接
+	if gw.t.Enabled() {
+		f.Cids = []uint32{cid}
+	}
+	rqId := rqIdFrom(ctx)
+	conns := gw.s().FilterConnections(f)
+	resp = &managev1.BroadcastFilterResponse{
+		Matched: int64(len(conns)),
+	}
+	if len(conns) > 0 {
+		resp.Sent = int64(gw.e().Multicast(conns, rqId, codec.NewAction(codec.ActionId(in.GetActionId()), in.GetActionName()), in.GetPbMessage(), in.GetJsonMessage(), gw.concurrency))
+	}
+	if in.GetCluster() && gw.r.routable(ctx) {
+		matched, sent, failed := gw.r.BroadcastFilter(ctx, rqId, in)
+		resp.Matched += matched
+		resp.Sent += sent
+		resp.FailedGateways = failed
+	}
+	return
+}
+
+func (gw *BroadcastService) SetConnLabels(ctx context.Context, in *managev1.SetConnLabelsRequest) (resp *managev1.SetConnLabelsResponse, err error) {
+	if in.GetFd() == 0 {
+		err = status.New(codes.InvalidArgument, "param:Fd is required").Err()
+		return
+	}
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return
+	}
+	conn := gw.s().GetFdConn(int(in.GetFd()))
+	if conn == nil {
+		err = status.New(codes.NotFound, "connection not found").Err()
+		return
+	}
+	if !gw.t.Allowed(cid, conn, tenant.Violation{Method: "SetConnLabels", RqId: rqIdFrom(ctx)}) {
+		err = status.New(codes.PermissionDenied, "connection of other tenant").Err()
+		return
+	}
+	for k, v := range in.GetLabels() {
+		if v == "" {
+			conn.Context().DelLabel(k)
+		} else {
+			conn.Context().SetLabel(k, v)
+		}
+	}
+	resp = &managev1.SetConnLabelsResponse{}
+	return
+}
+
+// ToConnFilter 转换筛选条件
+func ToConnFilter(f *managev1.ConnFilter) socket.ConnFilter {
+	cf := socket.ConnFilter{
+		Types:  f.GetTypes(),
+		Cids:   f.GetCids(),
+		Attrs:  f.GetAttrs(),
+		Labels: f.GetLabels(),
+	}
+	for _, id := range f.GetIds() {
+		if id.GetType() != "" {
+			cf.Ids = append(cf.Ids, socket.ConnId{Type: id.GetType(), Id: id.GetId()})
+		}
+	}
+	return cf
+}
+
+// FromConnFilter 转换筛选条件
+func FromConnFilter(f socket.ConnFilter) *managev1.ConnFilter {
+	cf := &managev1.ConnFilter{
+		Types:  f.Types,
+		Cids:   f.Cids,
+		Attrs:  f.Attrs,
+		Labels: f.Labels,
+	}
+	for _, id := range f.Ids {
+		cf.Ids = append(cf.Ids, &managev1.FilterId{Type: id.Type, Id: id.Id})
+	}
+	return cf
+}
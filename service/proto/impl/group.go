@@ -2,29 +2,44 @@ package impl
 
 import (
 	"context"
+	"github.com/obnahsgnaw/goutils/randutil"
 	groupv1 "github.com/obnahsgnaw/socketapi/gen/group/v1"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
 	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
 	"github.com/obnahsgnaw/socketutil/codec"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"strings"
+	"sync"
+	"time"
 )
 
 type GroupService struct {
 	groupv1.UnimplementedGroupServiceServer
-	s func() *socket.Server
-	e func() *eventhandler.Event
+	s           func() *socket.Server
+	e           func() *eventhandler.Event
+	t           *tenant.Guard
+	r           *Router
+	b           *recentKeys
+	concurrency int
 }
 
-func NewGroupService(s func() *socket.Server, e func() *eventhandler.Event) *GroupService {
+// NewGroupService r不为nil时, 广播会扇出到其他有该组成员的网关, concurrency为当前网关广播发送的并发数
+func NewGroupService(s func() *socket.Server, e func() *eventhandler.Event, t *tenant.Guard, r *Router, concurrency int) *GroupService {
 	return &GroupService{
-		s: s,
-		e: e,
+		s:           s,
+		e:           e,
+		t:           t,
+		r:           r,
+		b:           newRecentKeys(time.Minute),
+		concurrency: concurrency,
 	}
 }
 
-func (gw *GroupService) JoinGroup(_ context.Context, in *groupv1.JoinGroupRequest) (resp *groupv1.JoinGroupResponse, err error) {
+func (gw *GroupService) JoinGroup(ctx context.Context, in *groupv1.JoinGroupRequest) (resp *groupv1.JoinGroupResponse, err error) {
 	if in.GetGroup().Name == "" {
 		err = status.New(codes.InvalidArgument, "param:Group.Name is required").Err()
 		return
@@ -37,7 +52,18 @@ func (gw *GroupService) JoinGroup(_ context.Context, in *groupv1.JoinGroupReques
 		resp = &groupv1.JoinGroupResponse{}
 		return
 	}
-	gw.s().Groups().GetGroup(in.GetGroup().GetName()).Join(int(in.Member.GetFd()), in.Member.GetId())
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return
+	}
+	if conn := gw.s().GetFdConn(int(in.Member.GetFd())); conn != nil && !gw.t.Allowed(cid, conn, tenant.Violation{Method: "JoinGroup", RqId: rqIdFrom(ctx), Target: in.GetGroup().GetName()}) {
+		err = status.New(codes.PermissionDenied, "connection of other tenant").Err()
+		return
+	}
+	if err = gw.s().Groups().GetGroup(in.GetGroup().GetName()).TryJoin(int(in.Member.GetFd()), in.Member.GetId()); err != nil {
+		err = status.New(codes.ResourceExhausted, err.Error()).Err()
+		return
+	}
 
 	resp = &groupv1.JoinGroupResponse{}
 	return
@@ -52,50 +78,185 @@ func (gw *GroupService) LeaveGroup(_ context.Context, in *groupv1.LeaveGroupRequ
 		resp = &groupv1.LeaveGroupResponse{}
 		return
 	}
-	gw.s().Groups().GetGroup(in.GetGroup().GetName()).Leave(int(in.GetFd()))
+	if g, ok := gw.s().Groups().Group(in.GetGroup().GetName()); ok {
+		g.Leave(int(in.GetFd()))
+	}
 
 	resp = &groupv1.LeaveGroupResponse{}
 	return
 }
 
 func (gw *GroupService) BroadcastGroup(ctx context.Context, in *groupv1.BroadcastGroupRequest) (resp *groupv1.BroadcastGroupResponse, err error) {
-	var rqId string
-	md, ok := metadata.FromIncomingContext(ctx)
-	if ok {
-		ids := md.Get("rq_id")
-		if len(ids) > 0 {
-			rqId = ids[0]
-		}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if _, err = gw.broadcast(ctx, &managev1.BroadcastGroupRequest{
+		Name:        in.GetGroup().GetName(),
+		Id:          in.GetId(),
+		ActionId:    in.GetActionId(),
+		ActionName:  in.GetActionName(),
+		PbMessage:   in.GetPbMessage(),
+		JsonMessage: in.GetJsonMessage(),
+		LocalOnly:   len(md.Get(localOnlyKey)) > 0,
+	}); err != nil {
+		return
 	}
-	if in.GetGroup().Name == "" {
+	resp = &groupv1.BroadcastGroupResponse{}
+	return
+}
+
+// broadcast 向组成员广播, 按请求中的条件过滤成员, 返回扇出失败的网关
+func (gw *GroupService) broadcast(ctx context.Context, in *managev1.BroadcastGroupRequest) (failed []string, err error) {
+	rqId := rqIdFrom(ctx)
+	if in.GetName() == "" {
 		err = status.New(codes.InvalidArgument, "param:Group.Name is required").Err()
 		return
 	}
-	if in.ActionId == 0 {
+	if in.GetActionId() == 0 {
 		err = status.New(codes.InvalidArgument, "param:Action is required").Err()
 		return
 	}
-	if len(in.GetPbMessage()) == 0 || len(in.GetJsonMessage()) == 0 {
+	if len(in.GetPbMessage()) == 0 || (len(in.GetJsonMessage()) == 0 && !gw.e().Transcodable(codec.ActionId(in.GetActionId()))) {
 		err = status.New(codes.InvalidArgument, "param:Message is required").Err()
 		return
 	}
-	gw.s().Groups().GetGroup(in.GetGroup().GetName()).Broadcast(func(fd int, id string) {
-		if in.Id == "" || in.Id == id {
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return
+	}
+	// 发起的网关为广播生成id并随转发携带, 各网关按id只投递一次
+	key := broadcastId(ctx)
+	if gw.b.seen(key) {
+		return
+	}
+	filter := newBroadcastFilter(in)
+	if !in.GetLocalOnly() && gw.r.routable(ctx) {
+		defer func() {
+			if failed = gw.r.Broadcast(ctx, rqId, forwardBroadcast(in), broadcastKey, key); len(failed) > 0 && err == nil {
+				err = status.New(codes.Unavailable, "broadcast to gateway failed: "+strings.Join(failed, ",")).Err()
+			}
+		}()
+	}
+	g, ok := gw.s().Groups().Group(in.GetName())
+	if !ok {
+		return
+	}
+	var conns []socket.Conn
+	g.RangeMembers(func(fd int, id string) bool {
+		if in.GetId() == "" || in.GetId() == id {
 			conn := gw.s().GetFdConn(fd)
-
-			n, _ := conn.Context().GetOptional("coderName")
-			coderName := n.(codec.Name)
-			var msg []byte
-			if coderName == codec.Proto {
-				msg = in.PbMessage
-			} else {
-				msg = in.JsonMessage
+			if filter.allowed(conn, fd, id) && gw.t.Allowed(cid, conn, tenant.Violation{Method: "BroadcastGroup", RqId: rqId, Target: in.GetName()}) {
+				conns = append(conns, conn)
 			}
-
-			_ = gw.e().Send(conn, rqId, codec.NewAction(codec.ActionId(in.ActionId), in.ActionName), msg)
 		}
+		return true
 	})
-
-	resp = &groupv1.BroadcastGroupResponse{}
+	// 编码相同的成员只编码一次
+	gw.e().Multicast(conns, rqId, codec.NewAction(codec.ActionId(in.GetActionId()), in.GetActionName()), in.GetPbMessage(), in.GetJsonMessage(), gw.concurrency)
 	return
 }
+
+// broadcastId 返回转发来的请求中由发起网关设置的广播id, 当前网关发起的广播生成新的id
+func broadcastId(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(forwardedKey)) > 0 {
+		if ids := md.Get(broadcastKey); len(ids) > 0 {
+			return ids[0]
+		}
+		return ""
+	}
+	return randutil.RandAlphaNum(24)
+}
+
+// broadcastFilter 广播的成员过滤
+type broadcastFilter struct {
+	excludeFds map[int]struct{}
+	excludeIds map[string]struct{}
+	bindTypes  []string
+}
+
+func newBroadcastFilter(in *managev1.BroadcastGroupRequest) *broadcastFilter {
+	f := &broadcastFilter{
+		excludeFds: make(map[int]struct{}),
+		excludeIds: make(map[string]struct{}),
+		bindTypes:  in.GetBindTypes(),
+	}
+	for _, fd := range in.GetExcludeFds() {
+		f.excludeFds[int(fd)] = struct{}{}
+	}
+	for _, id := range in.GetExcludeIds() {
+		f.excludeIds[id] = struct{}{}
+	}
+	return f
+}
+
+func (f *broadcastFilter) allowed(c socket.Conn, fd int, id string) bool {
+	if c == nil {
+		return false
+	}
+	if _, ok := f.excludeFds[fd]; ok {
+		return false
+	}
+	if _, ok := f.excludeIds[id]; ok {
+		return false
+	}
+	if len(f.bindTypes) == 0 {
+		return true
+	}
+	for _, typ := range f.bindTypes {
+		if c.Context().TypedId(typ).Id != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardBroadcast 转发到其他网关的请求, fd只作用于当前网关, 不转发
+func forwardBroadcast(in *managev1.BroadcastGroupRequest) *managev1.BroadcastGroupRequest {
+	return &managev1.BroadcastGroupRequest{
+		Name:        in.GetName(),
+		Id:          in.GetId(),
+		ActionId:    in.GetActionId(),
+		ActionName:  in.GetActionName(),
+		PbMessage:   in.GetPbMessage(),
+		JsonMessage: in.GetJsonMessage(),
+		ExcludeIds:  in.GetExcludeIds(),
+		BindTypes:   in.GetBindTypes(),
+	}
+}
+
+// recentKeys 记录最近处理过的key
+type recentKeys struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	keys  map[string]time.Time
+	clean time.Time
+}
+
+func newRecentKeys(ttl time.Duration) *recentKeys {
+	return &recentKeys{
+		ttl:  ttl,
+		keys: make(map[string]time.Time),
+	}
+}
+
+// seen 返回key是否在ttl内已处理过, 并记录key, 空key总是返回false
+func (r *recentKeys) seen(key string) bool {
+	if key == "" {
+		return false
+	}
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.Sub(r.clean) > r.ttl {
+		for k, t := range r.keys {
+			if now.Sub(t) > r.ttl {
+				delete(r.keys, k)
+			}
+		}
+		r.clean = now
+	}
+	if t, ok := r.keys[key]; ok && now.Sub(t) <= r.ttl {
+		return true
+	}
+	r.keys[key] = now
+	return false
+}
@@ -0,0 +1,140 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/group"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+type GroupManageService struct {
+	managev1.UnimplementedGroupManageServiceServer
+	s func() *socket.Server
+	b *GroupService
+}
+
+// NewGroupManageService b用于组广播, 与GroupService共用去重与扇出
+func NewGroupManageService(s func() *socket.Server, b *GroupService) *GroupManageService {
+	return &GroupManageService{
+		s: s,
+		b: b,
+	}
+}
+
+func (gw *GroupManageService) ListGroups(_ context.Context, in *managev1.ListGroupsRequest) (resp *managev1.ListGroupsResponse, err error) {
+	offset, limit := pageRange(in.GetPage(), in.GetPageSize())
+	list, total := gw.s().Groups().List(in.GetPrefix(), offset, limit)
+	resp = &managev1.ListGroupsResponse{Total: int64(total)}
+	for _, g := range list {
+		resp.Groups = append(resp.Groups, groupInfo(g))
+	}
+	return
+}
+
+func (gw *GroupManageService) GetGroupInfo(_ context.Context, in *managev1.GetGroupInfoRequest) (resp *managev1.GetGroupInfoResponse, err error) {
+	g, err := gw.group(in.GetName())
+	if err != nil {
+		return
+	}
+	resp = &managev1.GetGroupInfoResponse{Group: groupInfo(g)}
+	return
+}
+
+func (gw *GroupManageService) SaveGroup(_ context.Context, in *managev1.SaveGroupRequest) (resp *managev1.SaveGroupResponse, err error) {
+	if in.GetName() == "" {
+		err = status.New(codes.InvalidArgument, "param:name is required").Err()
+		return
+	}
+	if in.GetTtl() < 0 || in.GetMaxMembers() < 0 {
+		err = status.New(codes.InvalidArgument, "param:ttl and max_members can not be negative").Err()
+		return
+	}
+	g := gw.s().Groups().CreateGroup(in.GetName(), group.Config{
+		Meta:       in.GetMeta(),
+		Ttl:        time.Duration(in.GetTtl()) * time.Second,
+		MaxMembers: int(in.GetMaxMembers()),
+		AutoDelete: in.GetAutoDelete(),
+	})
+	resp = &managev1.SaveGroupResponse{Group: groupInfo(g)}
+	return
+}
+
+func (gw *GroupManageService) DeleteGroup(_ context.Context, in *managev1.DeleteGroupRequest) (resp *managev1.DeleteGroupResponse, err error) {
+	g, err := gw.group(in.GetName())
+	if err != nil {
+		return
+	}
+	g.Destroy()
+	resp = &managev1.DeleteGroupResponse{}
+	return
+}
+
+func (gw *GroupManageService) ListGroupMembers(_ context.Context, in *managev1.ListGroupMembersRequest) (resp *managev1.ListGroupMembersResponse, err error) {
+	g, err := gw.group(in.GetName())
+	if err != nil {
+		return
+	}
+	offset, limit := pageRange(in.GetPage(), in.GetPageSize())
+	list, total := g.Members(offset, limit)
+	resp = &managev1.ListGroupMembersResponse{Total: int64(total)}
+	for _, m := range list {
+		resp.Members = append(resp.Members, &managev1.GroupMember{Fd: int64(m.Fd), Id: m.Id})
+	}
+	return
+}
+
+func (gw *GroupManageService) BroadcastGroup(ctx context.Context, in *managev1.BroadcastGroupRequest) (resp *managev1.BroadcastGroupResponse, err error) {
+	failed, err := gw.b.broadcast(ctx, in)
+	if err != nil && len(failed) == 0 {
+		return
+	}
+	// 部分网关失败时返回失败的网关
+	resp, err = &managev1.BroadcastGroupResponse{FailedGateways: failed}, nil
+	return
+}
+
+func (gw *GroupManageService) group(name string) (*group.Group, error) {
+	if name == "" {
+		return nil, status.New(codes.InvalidArgument, "param:name is required").Err()
+	}
+	g, ok := gw.s().Groups().Group(name)
+	if !ok {
+		return nil, status.New(codes.NotFound, "group not found").Err()
+	}
+	return g, nil
+}
+
+func groupInfo(g *group.Group) *managev1.GroupInfo {
+	c := g.Config()
+	return &managev1.GroupInfo{
+		Name:       g.Name(),
+		Meta:       c.Meta,
+		MemberNum:  int64(g.MemberNum()),
+		MaxMembers: int64(c.MaxMembers),
+		AutoDelete: c.AutoDelete,
+		CreatedAt:  toTimestamp(g.CreatedAt()),
+		ExpireAt:   toTimestamp(g.ExpireAt()),
+	}
+}
+
+// pageRange 页码从1开始, size为0时返回全部
+func pageRange(page, size int64) (offset, limit int) {
+	if page < 1 {
+		page = 1
+	}
+	if size <= 0 {
+		return 0, 0
+	}
+	return int((page - 1) * size), int(size)
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
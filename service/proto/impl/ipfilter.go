@@ -0,0 +1,59 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/ipfilter"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type IpFilterService struct {
+	managev1.UnimplementedIpFilterServiceServer
+	f *ipfilter.Filter
+}
+
+func NewIpFilterService(f *ipfilter.Filter) *IpFilterService {
+	return &IpFilterService{
+		f: f,
+	}
+}
+
+func (gw *IpFilterService) GetIpFilter(_ context.Context, _ *managev1.GetIpFilterRequest) (resp *managev1.GetIpFilterResponse, err error) {
+	resp = &managev1.GetIpFilterResponse{Rules: gw.rules()}
+	return
+}
+
+func (gw *IpFilterService) SetIpFilter(_ context.Context, in *managev1.SetIpFilterRequest) (resp *managev1.SetIpFilterResponse, err error) {
+	if err = gw.f.Set(in.GetRules().GetAllow(), in.GetRules().GetDeny()); err != nil {
+		err = status.New(codes.InvalidArgument, err.Error()).Err()
+		return
+	}
+	resp = &managev1.SetIpFilterResponse{Rules: gw.rules()}
+	return
+}
+
+func (gw *IpFilterService) AddIpFilter(_ context.Context, in *managev1.AddIpFilterRequest) (resp *managev1.AddIpFilterResponse, err error) {
+	if err = gw.f.Add(in.GetRules().GetAllow(), in.GetRules().GetDeny()); err != nil {
+		err = status.New(codes.InvalidArgument, err.Error()).Err()
+		return
+	}
+	resp = &managev1.AddIpFilterResponse{Rules: gw.rules()}
+	return
+}
+
+func (gw *IpFilterService) RemoveIpFilter(_ context.Context, in *managev1.RemoveIpFilterRequest) (resp *managev1.RemoveIpFilterResponse, err error) {
+	if err = gw.f.Remove(in.GetRules().GetAllow(), in.GetRules().GetDeny()); err != nil {
+		err = status.New(codes.InvalidArgument, err.Error()).Err()
+		return
+	}
+	resp = &managev1.RemoveIpFilterResponse{Rules: gw.rules()}
+	return
+}
+
+func (gw *IpFilterService) rules() *managev1.IpFilterRules {
+	return &managev1.IpFilterRules{
+		Allow: gw.f.Allows(),
+		Deny:  gw.f.Denies(),
+	}
+}
@@ -2,11 +2,11 @@ package impl
 
 import (
 	"context"
-	handlerv1 "github.com/obnahsgnaw/socketapi/gen/handler/v1"
+	"errors"
 	messagev1 "github.com/obnahsgnaw/socketapi/gen/message/v1"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
 	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
-	"github.com/obnahsgnaw/socketgateway/service/eventhandler/connutil"
 	"github.com/obnahsgnaw/socketutil/codec"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -17,12 +17,17 @@ type MessageService struct {
 	messagev1.UnimplementedMessageServiceServer
 	s func() *socket.Server
 	e func() *eventhandler.Event
+	t *tenant.Guard
+	r *Router
 }
 
-func NewMessageService(s func() *socket.Server, e func() *eventhandler.Event) *MessageService {
+// NewMessageService r不为nil时, 目标不在当前网关则转发到持有目标的网关
+func NewMessageService(s func() *socket.Server, e func() *eventhandler.Event, t *tenant.Guard, r *Router) *MessageService {
 	return &MessageService{
 		s: s,
 		e: e,
+		t: t,
+		r: r,
 	}
 }
 
@@ -40,90 +45,149 @@ func (gw *MessageService) SendMessage(ctx context.Context, in *messagev1.SendMes
 		err = status.New(codes.InvalidArgument, "param:ActionId is required").Err()
 		return
 	}
-	var cc []socket.Conn
-	if in.GetFd() > 0 {
-		ccc := gw.s().GetFdConn(int(in.GetFd()))
-		if ccc != nil {
-			cc = append(cc, ccc)
-		}
-	} else if in.GetId() != nil {
-		if in.GetId().Type == "TARGET" || in.GetId().Type == "SN" {
-			ccIds := gw.s().QueryProxyTargetBinds(in.GetId().Id)
-			if len(ccIds) > 0 {
-				for _, ccId := range ccIds {
-					ccc := gw.s().GetFdConn(ccId)
-					if ccc != nil {
-						cc = append(cc, ccc)
-					}
-				}
+	cc := gw.targetConns(in.GetFd(), in.GetId().GetType(), in.GetId().GetId())
+	if len(cc) == 0 && in.GetFd() <= 0 && in.GetId() != nil {
+		if gw.r.routable(ctx) {
+			if err = gw.r.SendMessage(ctx, rqId, in); err == nil {
+				resp = &messagev1.SendMessageResponse{}
 			}
+			return
 		}
-		if len(cc) == 0 {
-			cc = gw.s().GetIdConn(socket.ConnId{
-				Id:   in.GetId().Id,
-				Type: in.GetId().Type,
-			})
+		err = status.New(codes.NotFound, "connection not found or not support").Err()
+		return
+	}
+	if gw.t.Enabled() && len(cc) > 0 {
+		var cid uint32
+		if cid, err = tenantCid(ctx, gw.t); err != nil {
+			return
 		}
-		if len(cc) == 0 {
-			err = status.New(codes.NotFound, "connection not found or not support").Err()
+		if cc = gw.t.Filter(cid, cc, tenant.Violation{Method: "SendMessage", RqId: rqId, Target: in.GetId().GetId()}); len(cc) == 0 {
+			err = status.New(codes.PermissionDenied, "connection of other tenant").Err()
 			return
 		}
 	}
 	var send bool
 	var lastErr error
+	act := codec.NewAction(codec.ActionId(in.ActionId), in.ActionName)
 	for _, c := range cc {
-		coderName := connutil.CoderName(c)
+		if ok, _, err1 := gw.deliver(ctx, rqId, c, act, in.PbMessage, in.JsonMessage); ok {
+			send = true
+		} else {
+			lastErr = err1
+		}
+	}
+	// 发送到一个端即可
+	if !send {
+		err = lastErr
+	}
+
+	resp = &messagev1.SendMessageResponse{}
+	return
+}
+
+// targetConns 查找fd或绑定id对应的连接, fd优先, TARGET和SN类型优先查找代理目标
+func (gw *MessageService) targetConns(fd int64, typ, id string) (cc []socket.Conn) {
+	if fd > 0 {
+		if c := gw.s().GetFdConn(int(fd)); c != nil {
+			cc = append(cc, c)
+		}
+		return
+	}
+	if typ == "" || id == "" {
+		return
+	}
+	if typ == "TARGET" || typ == "SN" {
+		for _, ccId := range gw.s().QueryProxyTargetBinds(id) {
+			if c := gw.s().GetFdConn(ccId); c != nil {
+				cc = append(cc, c)
+			}
+		}
+	}
+	if len(cc) == 0 {
+		cc = gw.s().GetIdConn(socket.ConnId{
+			Id:   id,
+			Type: typ,
+		})
+	}
+	return
+}
+
+// deliverResult 向单个连接投递的结果
+type deliverResult int
+
+const (
+	delivered deliverResult = iota
+	encodeFailed
+	rawFailed
+	writeFailed
+)
+
+// deliver 向连接投递消息, 连接使用原始协议时由handler转换为原始数据并发送其子动作, 有一个发送成功即为成功
+func (gw *MessageService) deliver(ctx context.Context, rqId string, c socket.Conn, act codec.Action, pbMsg, jsonMsg []byte) (sent bool, result deliverResult, err error) {
+	if c.Context().Authentication().Protocol == "" {
 		var msg []byte
-		if c.Context().Authentication().Protocol != "" {
-			var subActions []*handlerv1.SubAction
-			if msg, subActions, lastErr = gw.e().ActionManager().Raw(c, rqId, gw.e().InternalDataCoder(), c.Context().Authentication().Protocol, in.PbMessage, in.ActionId); lastErr != nil {
-				continue
+		if msg, err = gw.e().Payload(c, act, pbMsg, jsonMsg); err != nil {
+			err = status.New(codes.Internal, "send message failed, err="+err.Error()).Err()
+			return false, encodeFailed, err
+		}
+		if err = gw.e().Send(c, rqId, act, msg); err != nil {
+			result = writeFailed
+			var packErr *eventhandler.PackError
+			if errors.As(err, &packErr) {
+				result = encodeFailed
 			}
-			if err = gw.e().SendRaw(c, msg); err != nil {
-				lastErr = status.New(codes.Internal, "send raw message failed, err="+err.Error()).Err()
+			err = status.New(codes.Internal, "send message failed, err="+err.Error()).Err()
+			return
+		}
+		return true, delivered, nil
+	}
+	msg, subActions, err := gw.e().ActionManager().Raw(c, rqId, gw.e().InternalDataCoder(), c.Context().Authentication().Protocol, pbMsg, uint32(act.Id))
+	if err != nil {
+		return false, rawFailed, err
+	}
+	result = writeFailed
+	if err1 := gw.e().SendRaw(c, msg); err1 != nil {
+		err = status.New(codes.Internal, "send raw message failed, err="+err1.Error()).Err()
+	} else {
+		sent = true
+	}
+	for _, subAction := range subActions {
+		if subAction.ActionId != 0 || len(subAction.Data) == 0 {
+			continue
+		}
+		subConn := c
+		if subAction.Target != "" {
+			conns := gw.e().SocketServer().GetAuthenticatedSnConn(subAction.Target)
+			if len(conns) > 0 {
+				subConn = conns[0]
 			} else {
-				send = true
+				subConn = nil
 			}
-			for _, subAction := range subActions {
-				if subAction.ActionId == 0 && len(subAction.Data) > 0 {
-					subConn := c
-					if subAction.Target != "" {
-						conns := gw.e().SocketServer().GetAuthenticatedSnConn(subAction.Target)
-						if len(conns) > 0 {
-							subConn = conns[0]
-						} else {
-							subConn = nil
-						}
-					}
-					if subConn == nil {
-						lastErr = status.New(codes.Internal, "send raw message failed, err=sub target conn not found").Err()
-						continue
-					}
-					if err = gw.e().SendRaw(subConn, subAction.Data); err != nil {
-						lastErr = status.New(codes.Internal, "send raw message failed, err="+err.Error()).Err()
-					} else {
-						send = true
-					}
+		}
+		if subConn == nil {
+			if gw.r.routable(ctx) {
+				if gw.r.SendRaw(ctx, rqId, socket.ConnId{Id: subAction.Target, Type: "SN"}, subAction.Data) == nil {
+					sent = true
+					continue
 				}
 			}
-		} else {
-			if coderName == codec.Proto {
-				msg = in.PbMessage
-			} else {
-				msg = in.JsonMessage
-			}
-			if err = gw.e().Send(c, rqId, codec.NewAction(codec.ActionId(in.ActionId), in.ActionName), msg); err != nil {
-				lastErr = status.New(codes.Internal, "send message failed, err="+err.Error()).Err()
-			} else {
-				send = true
+			err = status.New(codes.Internal, "send raw message failed, err=sub target conn not found").Err()
+			continue
+		}
+		if subConn != c && gw.t.Enabled() {
+			if cid, _ := tenantCid(ctx, gw.t); !gw.t.Allowed(cid, subConn, tenant.Violation{Method: "SendMessage", RqId: rqId, Target: subAction.Target}) {
+				err = status.New(codes.PermissionDenied, "send raw message failed, err=sub target conn of other tenant").Err()
+				continue
 			}
 		}
+		if err1 := gw.e().SendRaw(subConn, subAction.Data); err1 != nil {
+			err = status.New(codes.Internal, "send raw message failed, err="+err1.Error()).Err()
+		} else {
+			sent = true
+		}
 	}
-	// 发送到一个端即可
-	if !send {
-		err = lastErr
+	if sent {
+		result = delivered
 	}
-
-	resp = &messagev1.SendMessageResponse{}
 	return
 }
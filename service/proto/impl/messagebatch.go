@@ -0,0 +1,135 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"sync"
+)
+
+// maxBatchEntries 单次批量发送的最大消息数
+const maxBatchEntries = 10000
+
+type MessageBatchService struct {
+	managev1.UnimplementedMessageBatchServiceServer
+	m           *MessageService
+	concurrency int
+}
+
+// NewMessageBatchService concurrency为投递的并发数, r不为nil时目标不在当前网关则转发到持有目标的网关
+func NewMessageBatchService(s func() *socket.Server, e func() *eventhandler.Event, t *tenant.Guard, r *Router, concurrency int) *MessageBatchService {
+	if concurrency <= 0 {
+		concurrency = eventhandler.DefaultMulticastConcurrency
+	}
+	return &MessageBatchService{
+		m:           NewMessageService(s, e, t, r),
+		concurrency: concurrency,
+	}
+}
+
+func (gw *MessageBatchService) SendMessages(ctx context.Context, in *managev1.SendMessagesRequest) (resp *managev1.SendMessagesResponse, err error) {
+	entries := in.GetEntries()
+	if len(entries) == 0 {
+		err = status.New(codes.InvalidArgument, "param:Entries is required").Err()
+		return
+	}
+	if len(entries) > maxBatchEntries {
+		err = status.New(codes.InvalidArgument, "param:Entries exceeds "+strconv.Itoa(maxBatchEntries)).Err()
+		return
+	}
+	cid, err := tenantCid(ctx, gw.m.t)
+	if err != nil {
+		return
+	}
+	rqId := rqIdFrom(ctx)
+	resp = &managev1.SendMessagesResponse{
+		Results: make([]*managev1.MessageResult, len(entries)),
+	}
+	parallel(len(entries), gw.concurrency, func(index int) {
+		st, err1 := gw.send(ctx, rqId, cid, entries[index])
+		result := &managev1.MessageResult{Index: int64(index), Status: st}
+		if err1 != nil {
+			result.Error = status.Convert(err1).Message()
+		}
+		resp.Results[index] = result
+	})
+	for _, result := range resp.Results {
+		if result.Status == managev1.DeliveryStatus_DELIVERY_STATUS_DELIVERED {
+			resp.Delivered++
+		}
+	}
+	return
+}
+
+// parallel 以最多concurrency个协程对0到n-1执行fn, 全部完成后返回
+func parallel(n, concurrency int, fn func(index int)) {
+	if concurrency > n {
+		concurrency = n
+	}
+	var wg sync.WaitGroup
+	ch := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range ch {
+				fn(index)
+			}
+		}()
+	}
+	for index := 0; index < n; index++ {
+		ch <- index
+	}
+	close(ch)
+	wg.Wait()
+}
+
+// send 投递一条消息, 与SendMessage一致, 发送到目标的所有连接, 一个成功即为已投递
+func (gw *MessageBatchService) send(ctx context.Context, rqId string, cid uint32, in *managev1.MessageEntry) (managev1.DeliveryStatus, error) {
+	if in.GetActionId() == 0 {
+		return managev1.DeliveryStatus_DELIVERY_STATUS_INVALID, status.New(codes.InvalidArgument, "param:ActionId is required").Err()
+	}
+	if in.GetFd() <= 0 && (in.GetType() == "" || in.GetId() == "") {
+		return managev1.DeliveryStatus_DELIVERY_STATUS_INVALID, status.New(codes.InvalidArgument, "param:Fd or Type and Id is required").Err()
+	}
+	cc := gw.m.targetConns(in.GetFd(), in.GetType(), in.GetId())
+	if len(cc) == 0 {
+		if in.GetFd() <= 0 && gw.m.r.routable(ctx) {
+			return gw.m.r.SendEntry(ctx, rqId, in)
+		}
+		return managev1.DeliveryStatus_DELIVERY_STATUS_NOT_FOUND, status.New(codes.NotFound, "connection not found or not support").Err()
+	}
+	if gw.m.t.Enabled() {
+		if cc = gw.m.t.Filter(cid, cc, tenant.Violation{Method: "SendMessages", RqId: rqId, Target: in.GetId()}); len(cc) == 0 {
+			return managev1.DeliveryStatus_DELIVERY_STATUS_DENIED, status.New(codes.PermissionDenied, "connection of other tenant").Err()
+		}
+	}
+	var send bool
+	var result deliverResult
+	var lastErr error
+	act := codec.NewAction(codec.ActionId(in.GetActionId()), in.GetActionName())
+	for _, c := range cc {
+		if sent, r, err := gw.m.deliver(ctx, rqId, c, act, in.GetPbMessage(), in.GetJsonMessage()); sent {
+			send = true
+		} else {
+			result, lastErr = r, err
+		}
+	}
+	if send {
+		return managev1.DeliveryStatus_DELIVERY_STATUS_DELIVERED, nil
+	}
+	switch result {
+	case encodeFailed:
+		return managev1.DeliveryStatus_DELIVERY_STATUS_ENCODE_FAILED, lastErr
+	case rawFailed:
+		return managev1.DeliveryStatus_DELIVERY_STATUS_RAW_FAILED, lastErr
+	default:
+		return managev1.DeliveryStatus_DELIVERY_STATUS_WRITE_FAILED, lastErr
+	}
+}
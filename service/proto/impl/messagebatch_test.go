@@ -0,0 +1,142 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newMessageBatch() *MessageBatchService {
+	s := socket.New(context.Background(), sockettype.TCP, 0, nil, nil, nil, nil)
+	return NewMessageBatchService(func() *socket.Server { return s }, func() *eventhandler.Event { return nil }, nil, nil, 2)
+}
+
+func TestSendMessagesLimit(t *testing.T) {
+	gw := newMessageBatch()
+	cases := []struct {
+		name string
+		n    int
+		code codes.Code
+	}{
+		{"empty", 0, codes.InvalidArgument},
+		{"too many", maxBatchEntries + 1, codes.InvalidArgument},
+		{"max", maxBatchEntries, codes.OK},
+	}
+	for _, c := range cases {
+		entries := make([]*managev1.MessageEntry, c.n)
+		for i := range entries {
+			entries[i] = &managev1.MessageEntry{}
+		}
+		resp, err := gw.SendMessages(context.Background(), &managev1.SendMessagesRequest{Entries: entries})
+		if status.Code(err) != c.code {
+			t.Errorf("%s: got %v, want %v", c.name, status.Code(err), c.code)
+			continue
+		}
+		if err == nil && len(resp.GetResults()) != c.n {
+			t.Errorf("%s: got %d results, want %d", c.name, len(resp.GetResults()), c.n)
+		}
+	}
+}
+
+func TestSendMessagesStatus(t *testing.T) {
+	gw := newMessageBatch()
+	cases := []struct {
+		name  string
+		entry *managev1.MessageEntry
+		want  managev1.DeliveryStatus
+	}{
+		{"no action", &managev1.MessageEntry{Fd: 1}, managev1.DeliveryStatus_DELIVERY_STATUS_INVALID},
+		{"no target", &managev1.MessageEntry{ActionId: 101, Type: "USER"}, managev1.DeliveryStatus_DELIVERY_STATUS_INVALID},
+		{"fd not found", &managev1.MessageEntry{ActionId: 101, Fd: 1}, managev1.DeliveryStatus_DELIVERY_STATUS_NOT_FOUND},
+		{"id not found", &managev1.MessageEntry{ActionId: 101, Type: "USER", Id: "1"}, managev1.DeliveryStatus_DELIVERY_STATUS_NOT_FOUND},
+	}
+	entries := make([]*managev1.MessageEntry, len(cases))
+	for i, c := range cases {
+		entries[i] = c.entry
+	}
+	resp, err := gw.SendMessages(context.Background(), &managev1.SendMessagesRequest{Entries: entries})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range cases {
+		result := resp.GetResults()[i]
+		if result.GetIndex() != int64(i) || result.GetStatus() != c.want || result.GetError() == "" {
+			t.Errorf("%s: got %v", c.name, result)
+		}
+	}
+	if resp.GetDelivered() != 0 {
+		t.Error("need no delivered", resp.GetDelivered())
+	}
+}
+
+func TestParallel(t *testing.T) {
+	cases := []struct {
+		n, concurrency int
+	}{
+		{10, 3},
+		{2, 8},
+		{100, 1},
+	}
+	for _, c := range cases {
+		var running, peak, done int32
+		var mu sync.Mutex
+		seen := make(map[int]bool)
+		parallel(c.n, c.concurrency, func(index int) {
+			cur := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&done, 1)
+			mu.Lock()
+			seen[index] = true
+			mu.Unlock()
+		})
+		if int(done) != c.n || len(seen) != c.n {
+			t.Errorf("n=%d: handled %d, distinct %d", c.n, done, len(seen))
+		}
+		if int(peak) > c.concurrency {
+			t.Errorf("n=%d: peak %d exceeds concurrency %d", c.n, peak, c.concurrency)
+		}
+	}
+}
+
+func TestForwardedStatus(t *testing.T) {
+	peerErr := status.New(codes.Aborted, "encode failed").Err()
+	cases := []struct {
+		name   string
+		err    error
+		peerSt managev1.DeliveryStatus
+		want   managev1.DeliveryStatus
+		code   codes.Code
+	}{
+		{"delivered", nil, managev1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, managev1.DeliveryStatus_DELIVERY_STATUS_DELIVERED, codes.OK},
+		{"peer status", peerErr, managev1.DeliveryStatus_DELIVERY_STATUS_ENCODE_FAILED, managev1.DeliveryStatus_DELIVERY_STATUS_ENCODE_FAILED, codes.Aborted},
+		{"not found", status.New(codes.NotFound, "connection not found").Err(), managev1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, managev1.DeliveryStatus_DELIVERY_STATUS_NOT_FOUND, codes.NotFound},
+		{"transport", status.New(codes.DeadlineExceeded, "timeout").Err(), managev1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, managev1.DeliveryStatus_DELIVERY_STATUS_UNAVAILABLE, codes.Unavailable},
+		{"plain error", errors.New("dial failed"), managev1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED, managev1.DeliveryStatus_DELIVERY_STATUS_UNAVAILABLE, codes.Unavailable},
+	}
+	for _, c := range cases {
+		var pe error
+		if c.peerSt != managev1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED {
+			pe = peerErr
+		}
+		st, err := forwardedStatus(c.err, c.peerSt, pe)
+		if st != c.want || status.Code(err) != c.code {
+			t.Errorf("%s: got %v %v, want %v %v", c.name, st, status.Code(err), c.want, c.code)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/presence"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PresenceService struct {
+	managev1.UnimplementedPresenceServiceServer
+	i *presence.Index
+}
+
+func NewPresenceService(i *presence.Index) *PresenceService {
+	return &PresenceService{
+		i: i,
+	}
+}
+
+func (gw *PresenceService) SyncPresence(_ context.Context, in *managev1.SyncPresenceRequest) (resp *managev1.SyncPresenceResponse, err error) {
+	if in.GetHost() == "" {
+		err = status.New(codes.InvalidArgument, "param:host is required").Err()
+		return
+	}
+	gw.i.Apply(in.GetHost(), in.GetFull(), FromPresenceIds(in.GetAdded()), FromPresenceIds(in.GetRemoved()))
+	resp = &managev1.SyncPresenceResponse{}
+	return
+}
+
+func (gw *PresenceService) LocatePresence(_ context.Context, in *managev1.LocatePresenceRequest) (resp *managev1.LocatePresenceResponse, err error) {
+	if len(in.GetIds()) == 0 {
+		err = status.New(codes.InvalidArgument, "param:ids is required").Err()
+		return
+	}
+	resp = &managev1.LocatePresenceResponse{}
+	for _, id := range in.GetIds() {
+		resp.Locations = append(resp.Locations, &managev1.PresenceLocation{
+			Id:    id,
+			Hosts: gw.i.Locate(socket.ConnId{Type: id.GetType(), Id: id.GetId()}),
+		})
+	}
+	return
+}
+
+func ToPresenceIds(ids []socket.ConnId) (list []*managev1.PresenceId) {
+	for _, id := range ids {
+		list = append(list, &managev1.PresenceId{Type: id.Type, Id: id.Id})
+	}
+	return
+}
+
+func FromPresenceIds(ids []*managev1.PresenceId) (list []socket.ConnId) {
+	for _, id := range ids {
+		if id.GetType() != "" && id.GetId() != "" {
+			list = append(list, socket.ConnId{Type: id.GetType(), Id: id.GetId()})
+		}
+	}
+	return
+}
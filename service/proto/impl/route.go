@@ -0,0 +1,294 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/rpc/pkg/rpcclient"
+	messagev1 "github.com/obnahsgnaw/socketapi/gen/message/v1"
+	"github.com/obnahsgnaw/socketgateway/pkg/presence"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// forwardedKey 标记请求已由其他网关转发, 收到后不再转发, 避免环路
+	forwardedKey = "gw_forwarded"
+	// broadcastKey 广播id, 由发起的网关在转发时设置, 用于去重
+	broadcastKey = "broadcast_id"
+	// localOnlyKey 广播只投递到当前网关的成员, 用于GroupService, GroupManageService使用请求中的local_only
+	localOnlyKey = "local_only"
+)
+
+// ForwardTimeout 转发到其他网关的超时时间
+var ForwardTimeout = time.Second * 3
+
+// Router 将当前网关找不到的目标转发到持有目标的其他网关
+type Router struct {
+	m      *rpcclient.Manager
+	locate func(id socket.ConnId) []string
+}
+
+// NewRouter locate返回持有id的其他网关, 为nil时尝试所有网关
+func NewRouter(m *rpcclient.Manager, locate func(id socket.ConnId) []string) *Router {
+	return &Router{
+		m:      m,
+		locate: locate,
+	}
+}
+
+func (r *Router) peers(id socket.ConnId) []string {
+	if r.locate != nil {
+		return r.locate(id)
+	}
+	return r.m.Get("gateway")
+}
+
+// routable 请求可以被转发, 即未开启路由或请求本身是转发来的时不可转发
+func (r *Router) routable(ctx context.Context) bool {
+	if r == nil {
+		return false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return len(md.Get(forwardedKey)) == 0
+}
+
+// forwardMd 转发时携带的metadata, 公司id取自调用方的metadata或网关自身通过tenant.WithCid的声明
+func forwardMd(ctx context.Context, kv ...string) []string {
+	kv = append(kv, forwardedKey, "1")
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if cid := md.Get(tenant.MetadataKey); len(cid) > 0 {
+			return append(kv, tenant.MetadataKey, cid[0])
+		}
+	}
+	if cid := tenant.CidFrom(ctx); cid != 0 {
+		kv = append(kv, tenant.MetadataKey, strconv.FormatUint(uint64(cid), 10))
+	}
+	return kv
+}
+
+// forward 并发转发到持有目标的网关, 超时前有一个成功即为成功
+func (r *Router) forward(ctx context.Context, rqId string, id socket.ConnId, fn func(ctx context.Context, cc *grpc.ClientConn) error) error {
+	peers := r.peers(id)
+	if len(peers) == 0 {
+		return status.New(codes.NotFound, "connection not found or not support").Err()
+	}
+	kv := forwardMd(ctx)
+	ctx, cancel := context.WithTimeout(ctx, ForwardTimeout)
+	defer cancel()
+	errs := make(chan error, len(peers))
+	for _, gw := range peers {
+		go func(gw string) {
+			errs <- r.m.HostCall(ctx, gw, 0, "gateway", "gateway", rqId, "", "", func(ctx context.Context, cc *grpc.ClientConn) error {
+				return fn(metadata.AppendToOutgoingContext(ctx, kv...), cc)
+			})
+		}(gw)
+	}
+	var err error
+	ok := false
+	for range peers {
+		if err1 := <-errs; err1 == nil {
+			ok = true
+		} else if err == nil || status.Code(err) == codes.NotFound {
+			err = err1
+		}
+	}
+	if ok {
+		return nil
+	}
+	return err
+}
+
+// Broadcast 将组广播扇出到所有有该组成员的网关, kv为额外携带的metadata, 返回失败的网关
+func (r *Router) Broadcast(ctx context.Context, rqId string, in *managev1.BroadcastGroupRequest, kv ...string) (failed []string) {
+	kv = forwardMd(ctx, kv...)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, gw := range r.peers(presence.GroupId(in.GetName())) {
+		wg.Add(1)
+		go func(gw string) {
+			defer wg.Done()
+			if err := r.m.HostCall(ctx, gw, 0, "gateway", "gateway", rqId, "", "", func(ctx context.Context, cc *grpc.ClientConn) error {
+				_, err1 := managev1.NewGroupManageServiceClient(cc).BroadcastGroup(metadata.AppendToOutgoingContext(ctx, kv...), in)
+				return err1
+			}); err != nil {
+				mu.Lock()
+				failed = append(failed, gw)
+				mu.Unlock()
+			}
+		}(gw)
+	}
+	wg.Wait()
+	return
+}
+
+// SendMessage 转发消息到持有目标的网关
+func (r *Router) SendMessage(ctx context.Context, rqId string, in *messagev1.SendMessageRequest) error {
+	id := socket.ConnId{Id: in.GetId().GetId(), Type: in.GetId().GetType()}
+	return r.forward(ctx, rqId, id, func(ctx context.Context, cc *grpc.ClientConn) error {
+		_, err := messagev1.NewMessageServiceClient(cc).SendMessage(ctx, in)
+		return err
+	})
+}
+
+// SendEntry 将一条批量消息转发到持有目标的网关, 返回对端的投递结果, 转发失败时为DELIVERY_STATUS_UNAVAILABLE
+func (r *Router) SendEntry(ctx context.Context, rqId string, in *managev1.MessageEntry) (managev1.DeliveryStatus, error) {
+	id := socket.ConnId{Id: in.GetId(), Type: in.GetType()}
+	var mu sync.Mutex
+	var peerSt managev1.DeliveryStatus
+	var peerErr error
+	err := r.forward(ctx, rqId, id, func(ctx context.Context, cc *grpc.ClientConn) error {
+		resp, err := managev1.NewMessageBatchServiceClient(cc).SendMessages(ctx, &managev1.SendMessagesRequest{
+			Entries: []*managev1.MessageEntry{in},
+		})
+		if err != nil {
+			return err
+		}
+		if len(resp.GetResults()) == 0 {
+			return status.New(codes.Internal, "empty result").Err()
+		}
+		result := resp.GetResults()[0]
+		switch result.GetStatus() {
+		case managev1.DeliveryStatus_DELIVERY_STATUS_DELIVERED:
+			return nil
+		case managev1.DeliveryStatus_DELIVERY_STATUS_NOT_FOUND:
+			return status.New(codes.NotFound, result.GetError()).Err()
+		}
+		err = status.New(codes.Aborted, result.GetError()).Err()
+		mu.Lock()
+		if peerSt == managev1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED {
+			peerSt, peerErr = result.GetStatus(), err
+		}
+		mu.Unlock()
+		return err
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	return forwardedStatus(err, peerSt, peerErr)
+}
+
+// forwardedStatus 转发的投递结果, 对端的失败状态优先, 其次为连接不存在, 其余为转发失败
+func forwardedStatus(err error, peerSt managev1.DeliveryStatus, peerErr error) (managev1.DeliveryStatus, error) {
+	if err == nil {
+		return managev1.DeliveryStatus_DELIVERY_STATUS_DELIVERED, nil
+	}
+	if peerSt != managev1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED {
+		return peerSt, peerErr
+	}
+	if status.Code(err) == codes.NotFound {
+		return managev1.DeliveryStatus_DELIVERY_STATUS_NOT_FOUND, err
+	}
+	return managev1.DeliveryStatus_DELIVERY_STATUS_UNAVAILABLE, status.New(codes.Unavailable, status.Convert(err).Message()).Err()
+}
+
+// SendRaw 转发原始数据到持有目标的网关
+func (r *Router) SendRaw(ctx context.Context, rqId string, id socket.ConnId, data []byte) error {
+	return r.forward(ctx, rqId, id, func(ctx context.Context, cc *grpc.ClientConn) error {
+		_, err := managev1.NewRouteServiceClient(cc).RouteRaw(ctx, &managev1.RouteRawRequest{
+			Type: id.Type,
+			Id:   id.Id,
+			Data: data,
+		})
+		return err
+	})
+}
+
+type RouteService struct {
+	managev1.UnimplementedRouteServiceServer
+	s func() *socket.Server
+	e func() *eventhandler.Event
+	t *tenant.Guard
+}
+
+func NewRouteService(s func() *socket.Server, e func() *eventhandler.Event, t *tenant.Guard) *RouteService {
+	return &RouteService{
+		s: s,
+		e: e,
+		t: t,
+	}
+}
+
+func (gw *RouteService) RouteRaw(ctx context.Context, in *managev1.RouteRawRequest) (resp *managev1.RouteRawResponse, err error) {
+	if in.GetType() == "" || in.GetId() == "" {
+		err = status.New(codes.InvalidArgument, "param:type and id is required").Err()
+		return
+	}
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return
+	}
+	conns := gw.s().GetIdConn(socket.ConnId{Id: in.GetId(), Type: in.GetType()})
+	if len(conns) == 0 {
+		err = status.New(codes.NotFound, "connection not found").Err()
+		return
+	}
+	// 发送到目标的所有连接, 有一个成功即为成功
+	sent := false
+	for _, c := range conns {
+		if !gw.t.Allowed(cid, c, tenant.Violation{Method: "RouteRaw", RqId: rqIdFrom(ctx), Target: in.GetId()}) {
+			err = status.New(codes.PermissionDenied, "connection of other tenant").Err()
+			continue
+		}
+		if err1 := gw.e().SendRaw(c, in.GetData()); err1 != nil {
+			err = status.New(codes.Internal, "send raw message failed, err="+err1.Error()).Err()
+			continue
+		}
+		sent = true
+	}
+	if !sent {
+		return
+	}
+	resp, err = &managev1.RouteRawResponse{}, nil
+	return
+}
+
+// BroadcastFilter 将按条件广播扇出到所有其他网关, 返回其他网关的匹配数、发送数及失败的网关
+func (r *Router) BroadcastFilter(ctx context.Context, rqId string, in *managev1.BroadcastFilterRequest) (matched, sent int64, failed []string) {
+	return r.fanout(ctx, rqId, func(ctx context.Context, cc *grpc.ClientConn) (int64, int64, error) {
+		resp, err := managev1.NewBroadcastServiceClient(cc).BroadcastFilter(ctx, in)
+		return resp.GetMatched(), resp.GetSent(), err
+	})
+}
+
+// Publish 将主题发布扇出到所有其他网关, 返回其他网关的匹配数、发送数及失败的网关
+func (r *Router) Publish(ctx context.Context, rqId string, in *managev1.PublishRequest) (matched, sent int64, failed []string) {
+	return r.fanout(ctx, rqId, func(ctx context.Context, cc *grpc.ClientConn) (int64, int64, error) {
+		resp, err := managev1.NewTopicServiceClient(cc).Publish(ctx, in)
+		return resp.GetMatched(), resp.GetSent(), err
+	})
+}
+
+// fanout 并发调用所有其他网关, 汇总匹配数和发送数
+func (r *Router) fanout(ctx context.Context, rqId string, fn func(ctx context.Context, cc *grpc.ClientConn) (matched, sent int64, err error)) (matched, sent int64, failed []string) {
+	kv := forwardMd(ctx)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, gw := range r.m.Get("gateway") {
+		wg.Add(1)
+		go func(gw string) {
+			defer wg.Done()
+			var m, n int64
+			err := r.m.HostCall(ctx, gw, 0, "gateway", "gateway", rqId, "", "", func(ctx context.Context, cc *grpc.ClientConn) (err1 error) {
+				m, n, err1 = fn(metadata.AppendToOutgoingContext(ctx, kv...), cc)
+				return
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed = append(failed, gw)
+				return
+			}
+			matched += m
+			sent += n
+		}(gw)
+	}
+	wg.Wait()
+	return
+}
@@ -0,0 +1,34 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+func TestForwardMdCid(t *testing.T) {
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"none", context.Background(), ""},
+		{"caller", metadata.NewIncomingContext(context.Background(), metadata.Pairs(tenant.MetadataKey, "2")), "2"},
+		{"gateway", tenant.WithCid(context.Background(), 3), "3"},
+		{"caller first", tenant.WithCid(metadata.NewIncomingContext(context.Background(), metadata.Pairs(tenant.MetadataKey, "2")), 3), "2"},
+	}
+	for _, c := range cases {
+		md := metadata.Pairs(forwardMd(c.ctx)...)
+		if len(md.Get(forwardedKey)) != 1 {
+			t.Errorf("%s: need forwarded mark", c.name)
+		}
+		var got string
+		if v := md.Get(tenant.MetadataKey); len(v) > 0 {
+			got = v[0]
+		}
+		if got != c.want {
+			t.Errorf("%s: got company id %q, want %q", c.name, got, c.want)
+		}
+	}
+}
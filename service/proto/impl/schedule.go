@@ -0,0 +1,178 @@
+package impl
+
+import (
+	"context"
+	messagev1 "github.com/obnahsgnaw/socketapi/gen/message/v1"
+	"github.com/obnahsgnaw/socketgateway/pkg/schedule"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"time"
+)
+
+type ScheduleService struct {
+	managev1.UnimplementedScheduleServiceServer
+	sc  *schedule.Scheduler
+	m   *MessageService
+	ctx context.Context
+	l   *zap.Logger
+}
+
+// NewScheduleService 到期的消息通过m投递
+func NewScheduleService(ctx context.Context, l *zap.Logger, sc *schedule.Scheduler, m *MessageService) *ScheduleService {
+	return &ScheduleService{
+		sc:  sc,
+		m:   m,
+		ctx: ctx,
+		l:   l,
+	}
+}
+
+// Deliver 投递到期的消息, 以创建者声明的公司id做多租户隔离, 目标不在线等可恢复的失败返回错误由调度器重试
+func (gw *ScheduleService) Deliver(j schedule.Job) error {
+	if j.Fd > 0 && !gw.fdConnMatched(j) {
+		gw.l.Warn("scheduled message dropped, connection of fd closed", zap.String("schedule_id", j.Id), zap.Int("fd", j.Fd))
+		return schedule.Permanent(status.New(codes.NotFound, "connection of fd closed").Err())
+	}
+	md := metadata.Pairs("rq_id", "schedule-"+j.Id)
+	if gw.m.t.Enabled() {
+		md.Set(tenant.MetadataKey, strconv.FormatUint(uint64(j.Cid), 10))
+	}
+	in := &messagev1.SendMessageRequest{
+		Fd:          int64(j.Fd),
+		ActionId:    j.ActionId,
+		ActionName:  j.ActionName,
+		PbMessage:   j.PbMessage,
+		JsonMessage: j.JsonMessage,
+	}
+	if j.Fd <= 0 {
+		in.Id = &messagev1.Id{Type: j.Type, Id: j.TargetId}
+	}
+	_, err := gw.m.SendMessage(metadata.NewIncomingContext(gw.ctx, md), in)
+	if err == nil {
+		return nil
+	}
+	gw.l.Warn("scheduled message deliver failed, err="+err.Error(), zap.String("schedule_id", j.Id), zap.Int("fd", j.Fd), zap.String("type", j.Type), zap.String("id", j.TargetId), zap.Int("attempts", j.Attempts+1))
+	switch status.Code(err) {
+	case codes.NotFound, codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return err
+	default:
+		return schedule.Permanent(err)
+	}
+}
+
+// fdConnMatched fd当前的连接是否为创建任务时的连接
+func (gw *ScheduleService) fdConnMatched(j schedule.Job) bool {
+	c := gw.m.s().GetFdConn(j.Fd)
+	return c != nil && c.Context().ConnectedAt().Equal(j.ConnectedAt)
+}
+
+func (gw *ScheduleService) ScheduleMessage(ctx context.Context, in *managev1.ScheduleMessageRequest) (resp *managev1.ScheduleMessageResponse, err error) {
+	var at time.Time
+	if in.GetDeliverAt() != nil {
+		at = in.GetDeliverAt().AsTime()
+	} else if in.GetDelay() != nil {
+		at = time.Now().Add(in.GetDelay().AsDuration())
+	} else {
+		err = status.New(codes.InvalidArgument, "param:DeliverAt or Delay is required").Err()
+		return
+	}
+	if in.GetActionId() == 0 {
+		err = status.New(codes.InvalidArgument, "param:ActionId is required").Err()
+		return
+	}
+	if in.GetFd() <= 0 && (in.GetType() == "" || in.GetId() == "") {
+		err = status.New(codes.InvalidArgument, "param:Fd or Type and Id is required").Err()
+		return
+	}
+	cid, err := tenantCid(ctx, gw.m.t)
+	if err != nil {
+		return
+	}
+	// fd只在当前网关有效, 记录连接的建立时间, 避免投递到复用该fd的其他连接
+	var connectedAt time.Time
+	if in.GetFd() > 0 {
+		c := gw.m.s().GetFdConn(int(in.GetFd()))
+		if c == nil {
+			err = status.New(codes.NotFound, "connection not found").Err()
+			return
+		}
+		connectedAt = c.Context().ConnectedAt()
+	}
+	j, err := gw.sc.Add(schedule.Job{
+		At:          at,
+		Fd:          int(in.GetFd()),
+		ConnectedAt: connectedAt,
+		Type:        in.GetType(),
+		TargetId:    in.GetId(),
+		ActionId:    in.GetActionId(),
+		ActionName:  in.GetActionName(),
+		PbMessage:   in.GetPbMessage(),
+		JsonMessage: in.GetJsonMessage(),
+		Cid:         cid,
+	})
+	if err != nil {
+		err = status.New(codes.Internal, "schedule message failed, err="+err.Error()).Err()
+		return
+	}
+	resp = &managev1.ScheduleMessageResponse{
+		ScheduleId: j.Id,
+		DeliverAt:  toTimestamp(j.At),
+	}
+	return
+}
+
+func (gw *ScheduleService) CancelSchedule(ctx context.Context, in *managev1.CancelScheduleRequest) (resp *managev1.CancelScheduleResponse, err error) {
+	if in.GetScheduleId() == "" {
+		err = status.New(codes.InvalidArgument, "param:ScheduleId is required").Err()
+		return
+	}
+	cid, err := tenantCid(ctx, gw.m.t)
+	if err != nil {
+		return
+	}
+	resp = &managev1.CancelScheduleResponse{}
+	// 其他租户的消息视为不存在
+	if j, ok := gw.sc.Get(in.GetScheduleId()); !ok || (gw.m.t.Enabled() && j.Cid != cid) {
+		return
+	}
+	if resp.Cancelled, err = gw.sc.Cancel(in.GetScheduleId()); err != nil {
+		err = status.New(codes.Internal, "cancel schedule failed, err="+err.Error()).Err()
+	}
+	return
+}
+
+func (gw *ScheduleService) ListSchedules(ctx context.Context, in *managev1.ListSchedulesRequest) (resp *managev1.ListSchedulesResponse, err error) {
+	cid, err := tenantCid(ctx, gw.m.t)
+	if err != nil {
+		return
+	}
+	var filter func(j schedule.Job) bool
+	if gw.m.t.Enabled() {
+		filter = func(j schedule.Job) bool {
+			return j.Cid == cid
+		}
+	}
+	offset, limit := pageRange(in.GetPage(), in.GetPageSize())
+	list, total := gw.sc.List(filter, offset, limit)
+	resp = &managev1.ListSchedulesResponse{
+		Total: int64(total),
+	}
+	for _, j := range list {
+		resp.Items = append(resp.Items, &managev1.ScheduledMessage{
+			ScheduleId: j.Id,
+			DeliverAt:  toTimestamp(j.At),
+			Fd:         int64(j.Fd),
+			Type:       j.Type,
+			Id:         j.TargetId,
+			ActionId:   j.ActionId,
+			ActionName: j.ActionName,
+			CreatedAt:  toTimestamp(j.CreatedAt),
+		})
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"github.com/obnahsgnaw/socketgateway/pkg/schedule"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"net"
+	"testing"
+	"time"
+)
+
+type scheduleConn struct {
+	fd  int
+	ctx *socket.ConnContext
+	out int
+}
+
+func (c *scheduleConn) Fd() int                      { return c.fd }
+func (c *scheduleConn) Context() *socket.ConnContext { return c.ctx }
+func (c *scheduleConn) Read() ([]byte, error)        { return nil, nil }
+func (c *scheduleConn) Write([]byte) error           { c.out++; return nil }
+func (c *scheduleConn) Close()                       {}
+func (c *scheduleConn) LocalAddr() net.Addr          { return nil }
+func (c *scheduleConn) RemoteAddr() net.Addr         { return nil }
+
+func TestScheduleReusedFd(t *testing.T) {
+	ctx := context.Background()
+	s := socket.New(ctx, sockettype.TCP, 0, nil, &socket.BuiltinEvent{}, nil, nil)
+	sc := schedule.New(schedule.NewMemoryStore())
+	if err := sc.Start(ctx, func(schedule.Job) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	m := NewMessageService(func() *socket.Server { return s }, func() *eventhandler.Event { return nil }, nil, nil)
+	gw := NewScheduleService(ctx, zap.NewNop(), sc, m)
+
+	rq := &managev1.ScheduleMessageRequest{Delay: durationpb.New(time.Hour), Fd: 1, ActionId: 101}
+	if _, err := gw.ScheduleMessage(ctx, rq); status.Code(err) != codes.NotFound {
+		t.Fatalf("need reject offline fd, got %v", err)
+	}
+
+	old := &scheduleConn{fd: 1, ctx: socket.NewContext()}
+	s.Event().OnOpen(s, old)
+	resp, err := gw.ScheduleMessage(ctx, rq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, ok := sc.Get(resp.GetScheduleId())
+	if !ok || !gw.fdConnMatched(j) {
+		t.Fatal("need match the connection the job was created for")
+	}
+
+	// 原连接断开后fd被新连接复用
+	s.Event().OnClose(s, old, nil)
+	reused := &scheduleConn{fd: 1, ctx: socket.NewContext()}
+	s.Event().OnOpen(s, reused)
+	if gw.fdConnMatched(j) {
+		t.Fatal("need not match the connection reusing the fd")
+	}
+	if err = gw.Deliver(j); !schedule.IsPermanent(err) || status.Code(errors.Unwrap(err)) != codes.NotFound {
+		t.Fatalf("need permanent failure, got %v", err)
+	}
+	if reused.out != 0 {
+		t.Error("need not deliver to the connection reusing the fd")
+	}
+}
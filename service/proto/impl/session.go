@@ -0,0 +1,59 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SessionService struct {
+	managev1.UnimplementedSessionServiceServer
+	e func() *eventhandler.Event
+}
+
+func NewSessionService(e func() *eventhandler.Event) *SessionService {
+	return &SessionService{
+		e: e,
+	}
+}
+
+func (gw *SessionService) CountSessions(_ context.Context, in *managev1.CountSessionsRequest) (resp *managev1.CountSessionsResponse, err error) {
+	key, err := sessionKey(in.GetKey())
+	if err != nil {
+		return
+	}
+	resp = &managev1.CountSessionsResponse{Count: int64(len(gw.e().Sessions(key)))}
+	return
+}
+
+func (gw *SessionService) KickSessions(_ context.Context, in *managev1.KickSessionsRequest) (resp *managev1.KickSessionsResponse, err error) {
+	key, err := sessionKey(in.GetKey())
+	if err != nil {
+		return
+	}
+	reason := in.GetReason()
+	if reason == "" {
+		reason = "kicked by gateway"
+	}
+	resp = &managev1.KickSessionsResponse{Kicked: int64(gw.e().KickSessions(key, int(in.GetNum()), reason))}
+	return
+}
+
+func sessionKey(in *managev1.SessionKey) (key eventhandler.SessionKey, err error) {
+	if in.GetId() == "" {
+		err = status.New(codes.InvalidArgument, "param:key.id is required").Err()
+		return
+	}
+	if !in.GetUid() && in.GetType() == "" {
+		err = status.New(codes.InvalidArgument, "param:key.type is required").Err()
+		return
+	}
+	key = eventhandler.SessionKey{
+		Type: in.GetType(),
+		Id:   in.GetId(),
+		Uid:  in.GetUid(),
+	}
+	return
+}
@@ -0,0 +1,26 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tenantCid 解析调用方在metadata中声明的公司id, 未开启隔离时返回0
+func tenantCid(ctx context.Context, g *tenant.Guard) (cid uint32, err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if cid, err = g.Parse(md.Get(tenant.MetadataKey)); err != nil {
+		err = status.New(codes.PermissionDenied, err.Error()).Err()
+	}
+	return
+}
+
+func rqIdFrom(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get("rq_id"); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/socket"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+type TopicService struct {
+	managev1.UnimplementedTopicServiceServer
+	s           func() *socket.Server
+	e           func() *eventhandler.Event
+	t           *tenant.Guard
+	r           *Router
+	concurrency int
+}
+
+// NewTopicService concurrency为当前网关发送的并发数, r不为nil时支持发布到其他网关
+func NewTopicService(s func() *socket.Server, e func() *eventhandler.Event, t *tenant.Guard, r *Router, concurrency int) *TopicService {
+	return &TopicService{
+		s:           s,
+		e:           e,
+		t:           t,
+		r:           r,
+		concurrency: concurrency,
+	}
+}
+
+func (gw *TopicService) Publish(ctx context.Context, in *managev1.PublishRequest) (resp *managev1.PublishResponse, err error) {
+	if in.GetTopic() == "" || strings.ContainsAny(in.GetTopic(), "+#") {
+		err = status.New(codes.InvalidArgument, "param:Topic is required and can not contain wildcard").Err()
+		return
+	}
+	if in.GetActionId() == 0 {
+		err = status.New(codes.InvalidArgument, "param:ActionId is required").Err()
+		return
+	}
+	if len(in.GetPbMessage()) == 0 || (len(in.GetJsonMessage()) == 0 && !gw.e().Transcodable(codec.ActionId(in.GetActionId()))) {
+		err = status.New(codes.InvalidArgument, "param:Message is required").Err()
+		return
+	}
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return
+	}
+	rqId := rqIdFrom(ctx)
+	var conns []socket.Conn
+	for _, sub := range gw.s().Topics().Match(in.GetTopic()) {
+		if c := gw.s().GetFdConn(sub.Fd); c != nil {
+			conns = append(conns, c)
+		}
+	}
+	conns = gw.t.Filter(cid, conns, tenant.Violation{Method: "Publish", RqId: rqId, Target: in.GetTopic()})
+	resp = &managev1.PublishResponse{
+		Matched: int64(len(conns)),
+	}
+	if len(conns) > 0 {
+		resp.Sent = int64(gw.e().Multicast(conns, rqId, codec.NewAction(codec.ActionId(in.GetActionId()), in.GetActionName()), in.GetPbMessage(), in.GetJsonMessage(), gw.concurrency))
+	}
+	if in.GetCluster() && gw.r.routable(ctx) {
+		matched, sent, failed := gw.r.Publish(ctx, rqId, in)
+		resp.Matched += matched
+		resp.Sent += sent
+		resp.FailedGateways = failed
+	}
+	return
+}
+
+func (gw *TopicService) ListSubscriptions(ctx context.Context, in *managev1.ListSubscriptionsRequest) (resp *managev1.ListSubscriptionsResponse, err error) {
+	if in.GetFd() == 0 {
+		err = status.New(codes.InvalidArgument, "param:Fd is required").Err()
+		return
+	}
+	cid, err := tenantCid(ctx, gw.t)
+	if err != nil {
+		return
+	}
+	if c := gw.s().GetFdConn(int(in.GetFd())); c != nil && !gw.t.Allowed(cid, c, tenant.Violation{Method: "ListSubscriptions", RqId: rqIdFrom(ctx)}) {
+		err = status.New(codes.PermissionDenied, "connection of other tenant").Err()
+		return
+	}
+	resp = &managev1.ListSubscriptionsResponse{
+		Topics: gw.s().Topics().Filters(int(in.GetFd())),
+	}
+	return
+}
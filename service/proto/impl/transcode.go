@@ -0,0 +1,59 @@
+package impl
+
+import (
+	"context"
+	"github.com/obnahsgnaw/socketgateway/pkg/transcode"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
+	"github.com/obnahsgnaw/socketutil/codec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type TranscodeService struct {
+	managev1.UnimplementedTranscodeServiceServer
+	r *transcode.Registry
+}
+
+func NewTranscodeService(r *transcode.Registry) *TranscodeService {
+	return &TranscodeService{
+		r: r,
+	}
+}
+
+func (gw *TranscodeService) RegisterDescriptors(_ context.Context, in *managev1.RegisterDescriptorsRequest) (resp *managev1.RegisterDescriptorsResponse, err error) {
+	if len(in.GetFileDescriptorSet()) == 0 || len(in.GetMessages()) == 0 {
+		err = status.New(codes.InvalidArgument, "param:FileDescriptorSet and Messages is required").Err()
+		return
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(in.GetFileDescriptorSet(), set); err != nil {
+		err = status.New(codes.InvalidArgument, "param:FileDescriptorSet invalid, err="+err.Error()).Err()
+		return
+	}
+	messages := make(map[codec.ActionId]string, len(in.GetMessages()))
+	for _, m := range in.GetMessages() {
+		if m.GetActionId() == 0 || m.GetMessage() == "" {
+			err = status.New(codes.InvalidArgument, "param:Messages.ActionId and Message is required").Err()
+			return
+		}
+		messages[codec.ActionId(m.GetActionId())] = m.GetMessage()
+	}
+	if err = gw.r.RegisterFiles(set, messages); err != nil {
+		err = status.New(codes.InvalidArgument, err.Error()).Err()
+		return
+	}
+	resp = &managev1.RegisterDescriptorsResponse{}
+	return
+}
+
+func (gw *TranscodeService) UnregisterDescriptors(_ context.Context, in *managev1.UnregisterDescriptorsRequest) (resp *managev1.UnregisterDescriptorsResponse, err error) {
+	ids := make([]codec.ActionId, 0, len(in.GetActionIds()))
+	for _, id := range in.GetActionIds() {
+		ids = append(ids, codec.ActionId(id))
+	}
+	gw.r.Unregister(ids...)
+	resp = &managev1.UnregisterDescriptorsResponse{}
+	return
+}
@@ -1,6 +1,7 @@
 package socketgateway
 
 import (
+	"context"
 	"errors"
 	"github.com/obnahsgnaw/application"
 	"github.com/obnahsgnaw/application/endtype"
@@ -18,20 +19,30 @@ import (
 	groupv1 "github.com/obnahsgnaw/socketapi/gen/group/v1"
 	messagev1 "github.com/obnahsgnaw/socketapi/gen/message/v1"
 	slbv1 "github.com/obnahsgnaw/socketapi/gen/slb/v1"
+	"github.com/obnahsgnaw/socketgateway/pkg/abuse"
+	"github.com/obnahsgnaw/socketgateway/pkg/authcache"
+	"github.com/obnahsgnaw/socketgateway/pkg/group"
+	"github.com/obnahsgnaw/socketgateway/pkg/ipfilter"
 	"github.com/obnahsgnaw/socketgateway/pkg/mqtt"
+	"github.com/obnahsgnaw/socketgateway/pkg/presence"
+	"github.com/obnahsgnaw/socketgateway/pkg/schedule"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket/engine"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket/engine/custom/http"
 	mqtt2 "github.com/obnahsgnaw/socketgateway/pkg/socket/engine/custom/mqtt"
 	"github.com/obnahsgnaw/socketgateway/pkg/socket/sockettype"
+	"github.com/obnahsgnaw/socketgateway/pkg/tenant"
+	"github.com/obnahsgnaw/socketgateway/pkg/transcode"
 	"github.com/obnahsgnaw/socketgateway/service/action"
 	"github.com/obnahsgnaw/socketgateway/service/doc"
 	"github.com/obnahsgnaw/socketgateway/service/eventhandler"
 	"github.com/obnahsgnaw/socketgateway/service/manage"
 	gatewayv1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/gateway/v1"
+	managev1 "github.com/obnahsgnaw/socketgateway/service/proto/gen/manage/v1"
 	"github.com/obnahsgnaw/socketgateway/service/proto/impl"
 	"github.com/obnahsgnaw/socketutil/codec"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"strconv"
 	"strings"
@@ -71,8 +82,32 @@ type Server struct {
 	regEnable       bool
 	reuseAddr       bool
 	keepalive       uint
-	tickInterval    time.Duration
+	admission       socket.AdmissionConfig
+	sizeLimit       socket.SizeLimit
+	writeQueue      *socket.WriteQueueConfig
+	drain           *socket.DrainConfig
+	drainReconnect  string
+	drained         bool
+	ipFilter        *ipfilter.Filter
+	abuse           *abuse.Detector
+	tenant          *tenant.Guard
+	presenceSync    time.Duration
+	noRouting       bool
+	groupDefault    *group.Config
+	groupSweep      time.Duration
+	groupIdle       time.Duration
+	multicastLimit  int
+	transcoder      *transcode.Registry
+	topicEnabled    bool
+	topicAcl        TopicAcl
+	topicLimit      int
+	scheduler       *schedule.Scheduler
+	scheduleSvc     *impl.ScheduleService
+	presence        *presence.Index
+	presencePub     *presence.Publisher
 	authProvider    AuthProvider
+	authCacheCnf    *authcache.Config
+	authCache       *authcache.Cache
 	running         bool
 	watchGwRegInfo  *regCenter.RegInfo // watch gateway
 	watchClient     *rpcclient.Manager
@@ -91,6 +126,15 @@ type AuthProvider interface {
 	GetIdUser(uid string) (*socket.AuthUser, error)
 }
 
+// TopicAcl 决定连接能否订阅主题过滤器
+type TopicAcl func(c socket.Conn, filter string) bool
+
+// ExpiringAuthProvider 可返回认证过期时间的AuthProvider, 过期时间为零值时不过期
+type ExpiringAuthProvider interface {
+	AuthProvider
+	GetAuthedUserWithExpire(token string) (*socket.AuthUser, time.Time, error)
+}
+
 func New(app *application.Application, st sockettype.SocketType, et endtype.EndType, host url.Host, businessChannel string, options ...Option) *Server {
 	var err error
 	s := &Server{
@@ -104,6 +148,8 @@ func New(app *application.Application, st sockettype.SocketType, et endtype.EndT
 		app:             app,
 		actManager:      action.NewManager(),
 		watchClient:     rpcclient.NewManager(),
+		ipFilter:        ipfilter.New(),
+		sizeLimit:       socket.SizeLimit{MaxFrame: socket.DefaultMaxFrame},
 	}
 	if s.rawServerType == "" {
 		s.addErr(errors.New(s.msg("type not support")))
@@ -127,8 +173,12 @@ func New(app *application.Application, st sockettype.SocketType, et endtype.EndT
 		SecurityEncode:    false,
 	}, func(c socket.Conn, act codec.Action, pkg []byte) error {
 		return s.eventHandler.Send(c, "", act, pkg)
-	})
+	}, manage.IpFilter(s.ipFilter), manage.AbuseDetector(s.abuse))
 	s.managerTrigger = manage.NewTrigger(s.manager)
+	s.ipFilter.Listen(s.closeIpDenied)
+	if s.abuse != nil {
+		s.abuse.Listen(s.closeIpBanned)
+	}
 
 	return s
 }
@@ -197,7 +247,12 @@ func (s *Server) Release() {
 	var cb = func(msg string) {
 		s.logger.Debug(msg)
 	}
-	if s.RegEnabled() && s.app.Register() != nil {
+	if s.drain != nil && s.running && !s.drained {
+		if err := s.Drain(context.Background(), s.drainReconnect); err != nil {
+			s.logger.Warn("drain failed, err=" + err.Error())
+		}
+	}
+	if s.RegEnabled() && s.app.Register() != nil && !s.drained {
 		_ = s.app.DoUnregister(s.regInfo, cb)
 	}
 	if s.docServer != nil && s.app.Register() != nil {
@@ -211,6 +266,46 @@ func (s *Server) Release() {
 	s.running = false
 }
 
+// Drain 优雅下线: 从注册中心注销, 停止接受新连接, 通知客户端网关下线并建议重连到reconnect(可为空),
+// 等待处理中的消息完成或超时后分批关闭剩余连接, 最后停止socket服务
+// 排空未能开始时恢复注册, 排空开始后即使ctx结束也会关闭剩余连接并停止服务
+func (s *Server) Drain(ctx context.Context, reconnect string) error {
+	if s.server == nil || s.eventHandler == nil {
+		return errors.New(s.msg("server not running"))
+	}
+	if s.drained || s.server.Draining() {
+		return socket.ErrDraining
+	}
+	cb := func(msg string) {
+		s.logger.Debug(msg)
+	}
+	unregistered := false
+	if s.RegEnabled() && s.app.Register() != nil {
+		_ = s.app.DoUnregister(s.regInfo, cb)
+		unregistered = true
+	}
+	var c socket.DrainConfig
+	if s.drain != nil {
+		c = *s.drain
+	}
+	s.logger.Info(utils.ToStr("drain start, connections=", strconv.Itoa(s.server.ConnectionNum()), ", inflight=", strconv.FormatInt(s.server.Inflight(), 10)))
+	err := s.eventHandler.Drain(ctx, reconnect, c)
+	if !s.server.Draining() {
+		if unregistered {
+			if err1 := s.app.DoRegister(s.regInfo, cb); err1 != nil {
+				s.logger.Error("drain rollback register failed, err=" + err1.Error())
+			}
+		}
+		return err
+	}
+	s.drained = true
+	if err != nil {
+		return err
+	}
+	s.logger.Info("drained")
+	return nil
+}
+
 func (s *Server) Run(failedCb func(error)) {
 	if s.running {
 		return
@@ -247,18 +342,60 @@ func (s *Server) Run(failedCb func(error)) {
 	} else {
 		s.logger.Info("socket engine initialize(customer)")
 	}
+	if s.authCacheCnf != nil && s.authProvider != nil && s.authCache == nil {
+		s.authCache = authcache.New(s.authProvider, *s.authCacheCnf)
+		s.authProvider = s.authCache
+		s.addEventOption(eventhandler.Auth(s.authCache))
+	}
+	s.initPresence()
+	if r := s.router(); r != nil {
+		s.addEventOption(eventhandler.RawRouter(func(ctx context.Context, rqId, target string, data []byte) error {
+			if s.rpcServer == nil {
+				return errors.New("rpc server not initialized")
+			}
+			return r.SendRaw(ctx, rqId, socket.ConnId{Id: target, Type: "SN"}, data)
+		}))
+	}
 	s.addEventOption(eventhandler.Logger(s.logger))
 	s.addEventOption(eventhandler.Manage(s.managerTrigger))
 	s.eventHandler = eventhandler.New(s.app.Context(), s.actManager, s.rawSocketType, s.eo...)
+	s.eventHandler.SetIpInterceptor(s.ipFilterIntercept)
 	s.server = socket.New(s.app.Context(), s.rawSocketType, s.host.Port, s.engine, s.eventHandler, &socket.Config{
-		MultiCore: true,
-		Keepalive: s.keepalive,
-		NoDelay:   true,
-		Ticker:    s.tickInterval > 0,
-		ReuseAddr: s.reuseAddr,
+		MultiCore:  true,
+		Keepalive:  s.keepalive,
+		NoDelay:    true,
+		ReuseAddr:  s.reuseAddr,
+		Admission:  &s.admission,
+		SizeLimit:  s.sizeLimit,
+		WriteQueue: s.writeQueue,
 	}, s.watchClient)
+	if s.groupDefault != nil {
+		s.server.Groups().SetDefault(*s.groupDefault)
+	}
+	s.server.Topics().SetLimit(s.topicLimit)
+	// 有组设置了过期时间或自动删除时才开始清理
+	s.server.Groups().OnSweepable(func() {
+		go s.sweepGroups()
+	})
+	if s.scheduler != nil {
+		s.scheduleSvc = impl.NewScheduleService(s.app.Context(), s.logger, s.scheduler, impl.NewMessageService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }, s.tenant, s.router()))
+		if err := s.scheduler.Start(s.app.Context(), s.scheduleSvc.Deliver); err != nil {
+			failedCb(s.socketGwError(s.msg("scheduler start failed"), err))
+			return
+		}
+	}
+	if s.presencePub != nil {
+		s.server.ListenPresence(s.presencePub.Changed)
+		s.server.Groups().Listen(func(name string, present bool) {
+			s.presencePub.Changed(presence.GroupId(name), present)
+		})
+		go s.presencePub.Run(s.app.Context())
+	}
 	s.logger.Info("socket server initialized")
 	s.defaultListen()
+	if s.topicEnabled {
+		s.topicListen()
+	}
 	for _, h := range s.actListeners {
 		h(s.actManager)
 	}
@@ -382,30 +519,282 @@ func (s *Server) initRpc() {
 	if s.rpcServer != nil {
 		s.rpcServer.RegisterService(rpc2.ServiceInfo{
 			Desc: bindv1.BindService_ServiceDesc,
-			Impl: impl.NewBindService(func() *socket.Server { return s.server }),
+			Impl: impl.NewBindService(func() *socket.Server { return s.server }, s.tenant),
 		})
 		s.rpcServer.RegisterService(rpc2.ServiceInfo{
 			Desc: connv1.ConnService_ServiceDesc,
 			Impl: impl.NewConnService(func() *socket.Server { return s.server }),
 		})
+		groupSvc := impl.NewGroupService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }, s.tenant, s.router(), s.multicastLimit)
 		s.rpcServer.RegisterService(rpc2.ServiceInfo{
 			Desc: groupv1.GroupService_ServiceDesc,
-			Impl: impl.NewGroupService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }),
+			Impl: groupSvc,
 		})
 		s.rpcServer.RegisterService(rpc2.ServiceInfo{
 			Desc: messagev1.MessageService_ServiceDesc,
-			Impl: impl.NewMessageService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }),
+			Impl: impl.NewMessageService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }, s.tenant, s.router()),
 		})
 		s.rpcServer.RegisterService(rpc2.ServiceInfo{
 			Desc: slbv1.SlbService_ServiceDesc,
 			Impl: impl.NewSlbService(func() *socket.Server { return s.server }),
 		})
+		s.rpcServer.RegisterService(rpc2.ServiceInfo{
+			Desc: managev1.IpFilterService_ServiceDesc,
+			Impl: impl.NewIpFilterService(s.ipFilter),
+		})
+		s.rpcServer.RegisterService(rpc2.ServiceInfo{
+			Desc: managev1.SessionService_ServiceDesc,
+			Impl: impl.NewSessionService(func() *eventhandler.Event { return s.eventHandler }),
+		})
+		s.rpcServer.RegisterService(rpc2.ServiceInfo{
+			Desc: managev1.GroupManageService_ServiceDesc,
+			Impl: impl.NewGroupManageService(func() *socket.Server { return s.server }, groupSvc),
+		})
+		s.rpcServer.RegisterService(rpc2.ServiceInfo{
+			Desc: managev1.BroadcastService_ServiceDesc,
+			Impl: impl.NewBroadcastService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }, s.tenant, s.router(), s.multicastLimit),
+		})
+		s.rpcServer.RegisterService(rpc2.ServiceInfo{
+			Desc: managev1.MessageBatchService_ServiceDesc,
+			Impl: impl.NewMessageBatchService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }, s.tenant, s.router(), s.multicastLimit),
+		})
+		if s.topicEnabled {
+			s.rpcServer.RegisterService(rpc2.ServiceInfo{
+				Desc: managev1.TopicService_ServiceDesc,
+				Impl: impl.NewTopicService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }, s.tenant, s.router(), s.multicastLimit),
+			})
+		}
+		s.rpcServer.RegisterService(rpc2.ServiceInfo{
+			Desc: managev1.RouteService_ServiceDesc,
+			Impl: impl.NewRouteService(func() *socket.Server { return s.server }, func() *eventhandler.Event { return s.eventHandler }, s.tenant),
+		})
+		if s.scheduleSvc != nil {
+			s.rpcServer.RegisterService(rpc2.ServiceInfo{
+				Desc: managev1.ScheduleService_ServiceDesc,
+				Impl: s.scheduleSvc,
+			})
+		}
+		if s.transcoder != nil {
+			s.rpcServer.RegisterService(rpc2.ServiceInfo{
+				Desc: managev1.TranscodeService_ServiceDesc,
+				Impl: impl.NewTranscodeService(s.transcoder),
+			})
+		}
+		if s.presence != nil {
+			s.rpcServer.RegisterService(rpc2.ServiceInfo{
+				Desc: managev1.PresenceService_ServiceDesc,
+				Impl: impl.NewPresenceService(s.presence),
+			})
+		}
 		s.actManager.With(action.CloseAction(closeAction))
 		s.actManager.With(action.Gateway(s.rpcServer.Host()))
 		s.actManager.With(action.RtHandler(impl.NewRemoteHandler(s.app.Context(), s.logger, s.businessChannel)))
 	}
 }
 
+// AuthCache 返回AuthProvider的缓存, 用于主动失效, 未开启时为nil
+func (s *Server) AuthCache() *authcache.Cache {
+	return s.authCache
+}
+
+// Presence 返回集群在线索引, 未开启时为nil
+func (s *Server) Presence() *presence.Index {
+	return s.presence
+}
+
+// initPresence 开启集群在线索引, 各网关通过rpc互相同步持有的绑定id
+func (s *Server) initPresence() {
+	if s.presenceSync <= 0 || s.rpcServer == nil || s.presence != nil {
+		return
+	}
+	local := func(id socket.ConnId) bool {
+		if s.server == nil {
+			return false
+		}
+		if id.Type == presence.GroupType {
+			return s.server.Groups().MemberNum(id.Id) > 0
+		}
+		return len(s.server.GetIdConn(id)) > 0
+	}
+	s.presence = presence.New(s.rpcServer.Host().String(), local)
+	s.presencePub = presence.NewPublisher(func() []socket.ConnId {
+		ids := s.server.Presences()
+		s.server.Groups().RangeGroups(func(g *group.Group) bool {
+			if g.MemberNum() > 0 {
+				ids = append(ids, presence.GroupId(g.Name()))
+			}
+			return true
+		})
+		return ids
+	}, local, s.syncPresence, s.presenceSync)
+	s.addEventOption(eventhandler.PeerLocator(s.peerLocate))
+}
+
+// sweepGroups 定时清理过期的组及无成员的自动删除组
+func (s *Server) sweepGroups() {
+	interval, idle := s.groupSweep, s.groupIdle
+	if interval <= 0 {
+		interval = time.Second * 30
+	}
+	if idle <= 0 {
+		idle = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.app.Context().Done():
+			return
+		case now := <-ticker.C:
+			if n := s.server.Groups().Sweep(now, idle); n > 0 {
+				s.logger.Debug(s.msg("groups swept: " + strconv.Itoa(n)))
+			}
+		}
+	}
+}
+
+// router 跨网关路由, 有在线索引时只转发到持有目标的网关
+func (s *Server) router() *impl.Router {
+	if s.noRouting {
+		return nil
+	}
+	if s.presence == nil {
+		return impl.NewRouter(s.watchClient, nil)
+	}
+	return impl.NewRouter(s.watchClient, s.peerLocate)
+}
+
+func (s *Server) peerLocate(id socket.ConnId) []string {
+	hosts := s.presence.Locate(id)
+	if len(hosts) > 0 && hosts[0] == s.presence.Host() {
+		hosts = hosts[1:]
+	}
+	return hosts
+}
+
+func (s *Server) presencePeer(host string, isDel bool) {
+	if s.presencePub == nil {
+		return
+	}
+	if isDel {
+		s.presencePub.RemovePeer(host)
+		s.presence.Drop(host)
+	} else {
+		s.presencePub.AddPeer(host)
+	}
+}
+
+func (s *Server) syncPresence(ctx context.Context, host string, full bool, added, removed []socket.ConnId) error {
+	return s.watchClient.HostCall(ctx, host, 0, "gateway", "gateway", "", "", "", func(ctx context.Context, cc *grpc.ClientConn) error {
+		_, err := managev1.NewPresenceServiceClient(cc).SyncPresence(ctx, &managev1.SyncPresenceRequest{
+			Host:    s.presence.Host(),
+			Full:    full,
+			Added:   impl.ToPresenceIds(added),
+			Removed: impl.ToPresenceIds(removed),
+		})
+		return err
+	})
+}
+
+// BroadcastFilter 向当前网关满足条件的连接发送action, cluster为true时同时广播到其他网关, 返回满足条件及发送成功的连接数
+// ctx通过tenant.WithCid声明公司id时只投递到该租户的连接, 开启租户隔离时广播到其他网关必须声明
+func (s *Server) BroadcastFilter(ctx context.Context, f socket.ConnFilter, a codec.Action, pbMsg, jsonMsg []byte, cluster bool) (matched, sent int, err error) {
+	if f.Empty() {
+		return 0, 0, errors.New("broadcast filter is empty")
+	}
+	if s.server == nil || s.eventHandler == nil {
+		return 0, 0, errors.New("socket server not initialized")
+	}
+	if err = s.clusterCid(ctx, cluster); err != nil {
+		return
+	}
+	conns := s.tenantConns(ctx, "BroadcastFilter", s.server.FilterConnections(f))
+	matched = len(conns)
+	if matched > 0 {
+		sent = s.eventHandler.Multicast(conns, "", a, pbMsg, jsonMsg, s.multicastLimit)
+	}
+	if !cluster || s.rpcServer == nil {
+		return
+	}
+	if r := s.router(); r != nil {
+		m, n, failed := r.BroadcastFilter(ctx, "", &managev1.BroadcastFilterRequest{
+			Filter:      impl.FromConnFilter(f),
+			ActionId:    uint32(a.Id),
+			ActionName:  a.Name,
+			PbMessage:   pbMsg,
+			JsonMessage: jsonMsg,
+		})
+		matched += int(m)
+		sent += int(n)
+		if len(failed) > 0 {
+			err = errors.New("broadcast to gateway failed: " + strings.Join(failed, ","))
+		}
+	}
+	return
+}
+
+// Transcoder 返回proto与json数据的转换, 未开启时为nil
+func (s *Server) Transcoder() *transcode.Registry {
+	return s.transcoder
+}
+
+// Scheduler 返回定时投递调度器, 未开启时为nil
+func (s *Server) Scheduler() *schedule.Scheduler {
+	return s.scheduler
+}
+
+// Tenant 返回多租户隔离, 未开启时为nil
+func (s *Server) Tenant() *tenant.Guard {
+	return s.tenant
+}
+
+// auditTenant 记录跨租户投递
+func (s *Server) auditTenant(audit func(tenant.Violation)) func(tenant.Violation) {
+	return func(v tenant.Violation) {
+		s.logger.Warn(s.msg("tenant isolation violation"), zap.String("method", v.Method), zap.String("rq_id", v.RqId),
+			zap.Uint32("cid", v.Cid), zap.Uint32("conn_cid", v.ConnCid), zap.Int("fd", v.Fd), zap.String("target", v.Target))
+		if audit != nil {
+			audit(v)
+		}
+	}
+}
+
+func (s *Server) IpFilter() *ipfilter.Filter {
+	return s.ipFilter
+}
+
+func (s *Server) ipFilterIntercept(c socket.Conn) error {
+	// 前置代理时等待解析出真实ip后再过滤, 首包未携带代理头的连接由事件处理关闭
+	if s.admission.ProxyProtocol && c.Context().RealIp() == "" {
+		return nil
+	}
+	if ip := socket.ConnIp(c); !s.ipFilter.Allowed(ip) {
+		return errors.New("ip " + ip + " denied")
+	}
+	return nil
+}
+
+// closeIpDenied 名单变更后关闭已被拒绝的连接
+func (s *Server) closeIpDenied() {
+	if s.server == nil {
+		return
+	}
+	s.server.RangeConnections(func(c socket.Conn) bool {
+		if err := s.ipFilterIntercept(c); err != nil {
+			s.eventHandler.Disconnect(c, gatewayv1.DisconnectNotice_Rejected, "close by ip filter: "+err.Error())
+		}
+		return true
+	})
+}
+
+// closeIpBanned 手动封禁ip后关闭该ip已建立的连接
+func (s *Server) closeIpBanned(ip string) {
+	if s.eventHandler == nil {
+		return
+	}
+	s.eventHandler.CloseBanned(ip)
+}
+
 func (s *Server) docConfig() *DocConfig {
 	return &DocConfig{
 		id:       s.id,
@@ -437,13 +826,25 @@ func (s *Server) defaultListen() {
 			return &gatewayv1.PingRequest{}
 		},
 		func(c socket.Conn, data codec.DataPtr) (respAction codec.Action, respData codec.DataPtr) {
+			q := data.(*gatewayv1.PingRequest)
 			respAction = action.New(gatewayv1.ActionId_Pong)
 			respData = &gatewayv1.PongResponse{
-				Timestamp: timestamppb.New(time.Now()),
+				Timestamp:         timestamppb.New(time.Now()),
+				HeartbeatInterval: int64(s.eventHandler.NegotiateHeartbeat(c, q.HeartbeatInterval) / time.Second),
 			}
 			return
 		},
 	)
+	// 主动心跳探测的响应
+	s.Listen(action.New(gatewayv1.ActionId_Pong),
+		func() codec.DataPtr {
+			return &gatewayv1.PongResponse{}
+		},
+		func(c socket.Conn, data codec.DataPtr) (respAction codec.Action, respData codec.DataPtr) {
+			c.Context().Pong()
+			return
+		},
+	)
 	s.Listen(action.New(gatewayv1.ActionId_AuthReq),
 		func() codec.DataPtr {
 			return &gatewayv1.AuthRequest{}
@@ -457,50 +858,201 @@ func (s *Server) defaultListen() {
 			respData = response
 			if c.Context().Authed() {
 				response.Success = true
+				response.ExpireAt = toTimestamp(c.Context().AuthExpireAt())
 				return
 			}
-			if s.authProvider == nil {
-				response.Success = true
-			} else {
-				u, err := s.authProvider.GetAuthedUser(q.Token)
-				if err != nil {
-					s.Logger().Warn(s.msg("auth action request resp error: err=" + err.Error()))
-					response.Success = false
+			expireAt, err := s.authByToken(c, q.Token)
+			if err != nil {
+				s.Logger().Warn(s.msg("auth action request resp error: err=" + err.Error()))
+				return
+			}
+			response.Success = true
+			response.ExpireAt = toTimestamp(expireAt)
+			return
+		},
+	)
+	s.Listen(action.New(gatewayv1.ActionId_AuthRefreshReq),
+		func() codec.DataPtr {
+			return &gatewayv1.AuthRefreshRequest{}
+		},
+		func(c socket.Conn, data codec.DataPtr) (respAction codec.Action, respData codec.DataPtr) {
+			q := data.(*gatewayv1.AuthRefreshRequest)
+			respAction = action.New(gatewayv1.ActionId_AuthRefreshResp)
+			response := &gatewayv1.AuthRefreshResponse{
+				Success: false,
+			}
+			respData = response
+			// token对应的用户需与authenticate的id一致
+			expireAt, err := s.authByToken(c, q.Token)
+			if err != nil {
+				s.Logger().Warn(s.msg("auth refresh request resp error: err=" + err.Error()))
+				return
+			}
+			response.Success = true
+			response.ExpireAt = toTimestamp(expireAt)
+			return
+		},
+	)
+}
+
+// topicListen 客户端订阅及取消订阅主题
+func (s *Server) topicListen() {
+	s.Listen(action.New(gatewayv1.ActionId_SubscribeReq),
+		func() codec.DataPtr {
+			return &gatewayv1.SubscribeRequest{}
+		},
+		func(c socket.Conn, data codec.DataPtr) (respAction codec.Action, respData codec.DataPtr) {
+			q := data.(*gatewayv1.SubscribeRequest)
+			respAction = action.New(gatewayv1.ActionId_SubscribeResp)
+			response := &gatewayv1.SubscribeResponse{}
+			respData = response
+			for _, f := range q.Topics {
+				result := &gatewayv1.TopicResult{Topic: f}
+				if s.topicAcl != nil && !s.topicAcl(c, f) {
+					result.Reason = "not allowed"
+				} else if err := s.server.Topics().Subscribe(c.Fd(), f); err != nil {
+					result.Reason = err.Error()
 				} else {
-					response.Success = true
-					if u.Attr == nil {
-						u.Attr = make(map[string]string)
-					}
-					uid, _ := u.Attr["user_id"]
-					cidStr, _ := u.Attr["company_id"]
-					cid, _ := strconv.Atoi(cidStr)
-					if uid != c.Context().Authentication().Id {
-						s.Logger().Warn(s.msg("auth action request resp error: uid diff of authenticate id", uid, c.Context().Authentication().Id))
-						response.Success = false
-						return
-					}
-					err = s.server.Authenticate(c, &socket.Authentication{
-						Type: c.Context().Authentication().Type,
-						Id:   uid,
-						Iid:  int32(u.Id),
-						Sn:   uid,
-						Cid:  uint32(cid),
-						Uid:  uint32(u.Id),
-					})
-					if err != nil {
-						s.Logger().Warn(s.msg("auth action request resp error: err=" + err.Error()))
-						response.Success = false
-						return
-					}
-					s.server.Auth(c, u)
+					result.Success = true
 				}
+				response.Results = append(response.Results, result)
+			}
+			return
+		},
+	)
+	s.Listen(action.New(gatewayv1.ActionId_UnsubscribeReq),
+		func() codec.DataPtr {
+			return &gatewayv1.UnsubscribeRequest{}
+		},
+		func(c socket.Conn, data codec.DataPtr) (respAction codec.Action, respData codec.DataPtr) {
+			q := data.(*gatewayv1.UnsubscribeRequest)
+			respAction = action.New(gatewayv1.ActionId_UnsubscribeResp)
+			if len(q.Topics) == 0 {
+				s.server.Topics().UnsubscribeAll(c.Fd())
+			}
+			for _, f := range q.Topics {
+				s.server.Topics().Unsubscribe(c.Fd(), f)
+			}
+			respData = &gatewayv1.UnsubscribeResponse{
+				Topics: s.server.Topics().Filters(c.Fd()),
 			}
-
 			return
 		},
 	)
 }
 
+// Publish 向当前网关订阅了主题的连接发送action, cluster为true时同时发布到其他网关, 返回订阅的及发送成功的连接数
+// 租户的处理同BroadcastFilter
+func (s *Server) Publish(ctx context.Context, topic string, a codec.Action, pbMsg, jsonMsg []byte, cluster bool) (matched, sent int, err error) {
+	if s.server == nil || s.eventHandler == nil {
+		return 0, 0, errors.New("socket server not initialized")
+	}
+	if err = s.clusterCid(ctx, cluster); err != nil {
+		return
+	}
+	var conns []socket.Conn
+	for _, sub := range s.server.Topics().Match(topic) {
+		if c := s.server.GetFdConn(sub.Fd); c != nil {
+			conns = append(conns, c)
+		}
+	}
+	conns = s.tenantConns(ctx, "Publish", conns)
+	matched = len(conns)
+	if matched > 0 {
+		sent = s.eventHandler.Multicast(conns, "", a, pbMsg, jsonMsg, s.multicastLimit)
+	}
+	if !cluster || s.rpcServer == nil {
+		return
+	}
+	if r := s.router(); r != nil {
+		m, n, failed := r.Publish(ctx, "", &managev1.PublishRequest{
+			Topic:       topic,
+			ActionId:    uint32(a.Id),
+			ActionName:  a.Name,
+			PbMessage:   pbMsg,
+			JsonMessage: jsonMsg,
+		})
+		matched += int(m)
+		sent += int(n)
+		if len(failed) > 0 {
+			err = errors.New("publish to gateway failed: " + strings.Join(failed, ","))
+		}
+	}
+	return
+}
+
+// clusterCid 开启租户隔离时, 其他网关会拒绝未声明公司id的转发, 提前返回错误
+func (s *Server) clusterCid(ctx context.Context, cluster bool) error {
+	if cluster && s.tenant.Enabled() && tenant.CidFrom(ctx) == 0 {
+		return tenant.ErrUndeclared
+	}
+	return nil
+}
+
+// tenantConns 开启租户隔离且ctx声明了公司id时, 过滤出该租户的连接
+func (s *Server) tenantConns(ctx context.Context, method string, conns []socket.Conn) []socket.Conn {
+	if cid := tenant.CidFrom(ctx); cid != 0 && s.tenant.Enabled() {
+		return s.tenant.Filter(cid, conns, tenant.Violation{Method: method})
+	}
+	return conns
+}
+
+// authByToken 通过token认证连接, 返回认证过期时间
+func (s *Server) authByToken(c socket.Conn, token string) (expireAt time.Time, err error) {
+	if s.authProvider == nil {
+		return
+	}
+	var u *socket.AuthUser
+	if p, ok := s.authProvider.(ExpiringAuthProvider); ok {
+		u, expireAt, err = p.GetAuthedUserWithExpire(token)
+	} else {
+		u, err = s.authProvider.GetAuthedUser(token)
+	}
+	if err != nil {
+		return
+	}
+	if !expireAt.IsZero() && !expireAt.After(time.Now()) {
+		err = errors.New("token expired")
+		return
+	}
+	if u.Attr == nil {
+		u.Attr = make(map[string]string)
+	}
+	uid, _ := u.Attr["user_id"]
+	cidStr, _ := u.Attr["company_id"]
+	cid, _ := strconv.Atoi(cidStr)
+	if uid != c.Context().Authentication().Id {
+		err = errors.New("uid diff of authenticate id, uid=" + uid + ", authenticate id=" + c.Context().Authentication().Id)
+		return
+	}
+	// 刷新认证时用户不变, 无需再按登录策略检查
+	// 先检查登录策略, 被拒绝的登录不改变连接的认证信息和绑定
+	if u1 := c.Context().User(); u1 == nil || u1.Id != u.Id {
+		if err = s.eventHandler.LoginCheck(c, "", eventhandler.SessionKey{Id: strconv.Itoa(int(u.Id)), Uid: true}); err != nil {
+			return
+		}
+	}
+	if err = s.server.Authenticate(c, &socket.Authentication{
+		Type: c.Context().Authentication().Type,
+		Id:   uid,
+		Iid:  int32(u.Id),
+		Sn:   uid,
+		Cid:  uint32(cid),
+		Uid:  uint32(u.Id),
+	}); err != nil {
+		return
+	}
+	s.server.AuthWithExpire(c, u, expireAt)
+	return
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
 func (s *Server) msg(msg ...string) string {
 	return utils.ToStr("Socket Server[", s.name, "] ", utils.ToStr(msg...))
 }
@@ -544,6 +1096,7 @@ func (s *Server) watch(register regCenter.Register) error {
 		segments := strings.Split(key, "/")
 		host := segments[len(segments)-1]
 		if host != s.rpcServer.Host().String() {
+			s.presencePeer(host, isDel)
 			return "gateway", host
 		}
 		return "", ""